@@ -2,18 +2,32 @@ package domain
 
 import (
 	"github.com/go-sql-driver/mysql"
+	"math"
 	"time"
 )
 
 type Book struct {
 	Base
-	AccountID    string       `json:"account_id"`
-	Title        string       `json:"title"`
-	Author       *Author      `json:"author"`
-	StartAt      NullTime     `json:"start_at"`
-	EndAt        NullTime     `json:"end_at"`
-	ReadState    ReadState    `json:"read_state"`
-	Descriptions Descriptions `json:"descriptions"`
+	AccountID     string        `json:"account_id"`
+	Title         string        `json:"title"`
+	Author        *Author       `json:"author"`
+	StartAt       NullTime      `json:"start_at"`
+	EndAt         NullTime      `json:"end_at"`
+	ReadState     ReadState     `json:"read_state"`
+	ReadCount     uint          `json:"read_count"`
+	TotalPages    uint          `json:"total_pages"`
+	Progress      *BookProgress `json:"progress"`
+	Rating        NullFloat64   `json:"rating"`
+	Review        string        `json:"review"`
+	ISBN          string        `json:"isbn"`
+	ISBN13        string        `json:"isbn13"`
+	Publisher     string        `json:"publisher"`
+	PublishedDate string        `json:"published_date"`
+	Cover         string        `json:"-"` // version of the uploaded cover, empty without one
+	CoverURL      string        `json:"cover_url"`
+	Tags          Tags          `json:"tags"`
+	Series        *BookSeries   `json:"series,omitempty"`
+	Descriptions  Descriptions  `json:"descriptions"`
 }
 
 type Books []Book
@@ -32,18 +46,47 @@ func NewBook() Book {
 }
 
 type PaginateBooks struct {
-	Books      Books `json:"books"`
-	TotalCount int64 `json:"total_count"`
+	Books      Books  `json:"books"`
+	TotalCount int64  `json:"total_count"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
+const (
+	MinRating = 1.0
+	MaxRating = 5.0
+)
+
 type ReadState int8
 
 const (
 	NotReadValue ReadState = iota + 1
 	ReadingValue
 	ReadValue
+	AbandonedValue
 )
 
+var readStateNames = map[ReadState]string{
+	NotReadValue:   "not_read",
+	ReadingValue:   "reading",
+	ReadValue:      "read",
+	AbandonedValue: "abandoned",
+}
+
+func (s ReadState) String() string {
+	return readStateNames[s]
+}
+
+// ParseReadState reads a state by the name String gives it.
+func ParseReadState(name string) (ReadState, error) {
+	for state, v := range readStateNames {
+		if v == name {
+			return state, nil
+		}
+	}
+	return 0, ErrUnknownReadState
+}
+
 //func (b *Book) GetReadState() ReadState {
 //	if b.StartAt.Valid && b.EndAt.Valid {
 //		return ReadValue
@@ -56,19 +99,57 @@ const (
 //	}
 //}
 
-func (b *Book) SetStartState() {
-	b.StartAt = NullTime{mysql.NullTime{Time: time.Now(), Valid: true}}
-	b.EndAt = NullTime{mysql.NullTime{Valid: false}}
-	b.ReadState = ReadingValue
+// Validate checks that the read dates agree with the read state and that the ISBNs are well formed.
+func (b *Book) Validate() error {
+	if b.Title == "" {
+		return ErrEmptyTitle
+	}
+	switch b.ReadState {
+	case NotReadValue:
+		if b.StartAt.Valid || b.EndAt.Valid {
+			return ErrInvalidReadState
+		}
+	case ReadingValue:
+		if !b.StartAt.Valid || b.EndAt.Valid {
+			return ErrInvalidReadState
+		}
+	case ReadValue, AbandonedValue:
+		if !b.StartAt.Valid || !b.EndAt.Valid || b.EndAt.Time.Before(b.StartAt.Time) {
+			return ErrInvalidReadState
+		}
+	default:
+		return ErrInvalidReadState
+	}
+	err := ValidateISBN(b.ISBN, b.ISBN13)
+	if err != nil {
+		return err
+	}
+	return ValidateRating(b.Rating)
 }
-func (b *Book) SetEndState() {
-	b.EndAt = NullTime{mysql.NullTime{Time: time.Now(), Valid: true}}
-	b.ReadState = ReadValue
+
+// ValidateRating accepts no rating, or 1 to 5 stars in half steps.
+func ValidateRating(rating NullFloat64) error {
+	if !rating.Valid {
+		return nil
+	}
+	r := rating.Float64
+	if r < MinRating || r > MaxRating || math.Mod(r*2, 1) != 0 {
+		return ErrInvalidRating
+	}
+	return nil
 }
 
 type Author struct {
 	Base
-	Name string `json:"name"`
+	AccountID string `json:"account_id"`
+	Name      string `json:"name"`
+}
+
+func (a *Author) Validate() error {
+	if a.Name == "" {
+		return ErrEmptyAuthorName
+	}
+	return nil
 }
 
 func (Author) TableName() string {
@@ -86,10 +167,30 @@ func (a Authors) FindById(id uint64) *Author {
 	return nil
 }
 
+// Description is a note on a book. SourceKey identifies a description imported from elsewhere,
+// so that importing the same source again does not duplicate it.
 type Description struct {
 	Base
-	BookId  uint64 `json:"book_id"`
-	Content string `json:"content"`
+	BookId    uint64   `json:"book_id"`
+	Content   string   `json:"content"`
+	EditedAt  NullTime `json:"edited_at"`
+	SourceKey string   `json:"-"`
+}
+
+func (d *Description) Validate() error {
+	if d.Content == "" {
+		return ErrEmptyContent
+	}
+	return nil
+}
+
+// Edit replaces the content and stamps the edit time when the content actually changes.
+func (d *Description) Edit(content string) {
+	if content == d.Content {
+		return
+	}
+	d.Content = content
+	d.EditedAt = NullTime{mysql.NullTime{Time: time.Now(), Valid: true}}
 }
 
 func (Description) TableName() string {
@@ -97,3 +198,10 @@ func (Description) TableName() string {
 }
 
 type Descriptions []Description
+
+type PaginateDescriptions struct {
+	Descriptions Descriptions `json:"descriptions"`
+	TotalCount   int64        `json:"total_count"`
+	NextCursor   string       `json:"next_cursor,omitempty"`
+	PrevCursor   string       `json:"prev_cursor,omitempty"`
+}
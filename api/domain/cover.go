@@ -0,0 +1,40 @@
+package domain
+
+import "fmt"
+
+// CoverSize is a size a cover image is stored in.
+type CoverSize string
+
+const (
+	ThumbnailCover CoverSize = "thumbnail"
+	FullCover      CoverSize = "full"
+)
+
+// coverDimensions are the largest width and height of each size; smaller images are kept as they are.
+var coverDimensions = map[CoverSize]int{
+	ThumbnailCover: 300,
+	FullCover:      1200,
+}
+
+var CoverSizes = []CoverSize{ThumbnailCover, FullCover}
+
+func (s CoverSize) IsValid() bool {
+	_, ok := coverDimensions[s]
+	return ok
+}
+
+// MaxDimension is the largest width and height of a cover of this size.
+func (s CoverSize) MaxDimension() int {
+	return coverDimensions[s]
+}
+
+// CoverURL is where the cover of a book is served. version changes with every upload,
+// so clients may cache the URL for good. Adding size=thumbnail gives the small image.
+func CoverURL(bookId uint64, version string) string {
+	return fmt.Sprintf("/book/%d/cover?v=%s", bookId, version)
+}
+
+// CoverKey is the storage key of a cover image.
+func CoverKey(bookId uint64, version string, size CoverSize) string {
+	return fmt.Sprintf("covers/%d/%s-%s.jpg", bookId, version, size)
+}
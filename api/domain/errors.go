@@ -0,0 +1,42 @@
+package domain
+
+import "errors"
+
+// ValidationError is returned when a domain value breaks one of its invariants.
+type ValidationError struct {
+	message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.message
+}
+
+var (
+	ErrEmptyTitle        = &ValidationError{"title must not be empty"}
+	ErrInvalidReadState  = &ValidationError{"read state and read dates are inconsistent"}
+	ErrUnknownReadState  = &ValidationError{"read state must be one of not_read, reading, read or abandoned"}
+	ErrEmptyAuthorName   = &ValidationError{"author name must not be empty"}
+	ErrEmptyTagName      = &ValidationError{"tag name must not be empty"}
+	ErrInvalidRating     = &ValidationError{"rating must be between 1 and 5 in half steps"}
+	ErrEmptySeriesTitle  = &ValidationError{"series title must not be empty"}
+	ErrEmptyContent      = &ValidationError{"content must not be empty"}
+	ErrEmptyQuote        = &ValidationError{"quote must not be empty"}
+	ErrInvalidLocation   = &ValidationError{"location must be a page or location range starting at 1"}
+	ErrInvalidProgress   = &ValidationError{"progress must be given as either a page within the book or a percentage from 0 to 100"}
+	ErrMissingTotalPages = &ValidationError{"total_pages must be set on the book to log progress by page"}
+	ErrInvalidColor      = &ValidationError{"color must be one of yellow, blue, pink, orange or green"}
+	ErrInvalidGoalYear   = &ValidationError{"goal year must be between 1 and 9999"}
+	ErrInvalidGoalTarget = &ValidationError{"goal target must be at least one book"}
+	ErrFutureReadDate    = &ValidationError{"read date must not be in the future"}
+	ErrReadDateOrder     = &ValidationError{"read end date must not be before the start date"}
+	ErrInvalidISBN10     = &ValidationError{"isbn must be a valid ISBN-10"}
+	ErrInvalidISBN13     = &ValidationError{"isbn13 must be a valid ISBN-13"}
+	ErrMissingISBN       = &ValidationError{"isbn or isbn13 must be set to look up book metadata"}
+	ErrInvalidCover      = &ValidationError{"cover must be a JPEG, PNG or GIF image"}
+	ErrCoverTooLarge     = &ValidationError{"cover must not be larger than 4000 by 4000 pixels"}
+	ErrInvalidCoverSize  = &ValidationError{"cover size must be thumbnail or full"}
+	ErrMissingEndDate    = &ValidationError{"a read or abandoned book needs the date it was finished"}
+	ErrMissingStartDate  = &ValidationError{"a book being read needs the date it was started"}
+)
+
+var ErrInvalidTransition = errors.New("read state transition is not allowed")
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ExportVersion is the version of the export schema, raised whenever LibraryExport changes incompatibly.
+// Version 1 held descriptions as plain strings; it is still read back.
+const ExportVersion = 2
+
+// LibraryExport is the JSON document produced by GET /export?format=json and read back by POST /import/json:
+//
+//	{
+//	  "version": 2,
+//	  "exported_at": "2026-01-02T15:04:05Z",
+//	  "books": [
+//	    {
+//	      "title": "Dune",
+//	      "author": "Frank Herbert",
+//	      "status": "read",               // not_read, reading, read or abandoned
+//	      "read_count": 2,
+//	      "start_at": "2025-12-01T00:00:00Z", // null when not set
+//	      "end_at": "2025-12-24T00:00:00Z",
+//	      "rating": 4.5,                  // null when not rated
+//	      "review": "",
+//	      "isbn": "0441013597",
+//	      "isbn13": "9780441013593",
+//	      "total_pages": 896,
+//	      "publisher": "Ace",
+//	      "published_date": "2005-08-02",
+//	      "cover_url": "/book/12/cover?v=1a2b3c", // empty without a cover
+//	      "tags": ["sf", "classics"],
+//	      "descriptions": [
+//	        {"content": "first note", "created_at": "2025-12-02T21:00:00Z", "edited_at": null},
+//	        {"content": "a highlight", "created_at": "2025-12-03T08:00:00Z", "edited_at": null, "source_key": "3f2a…"}
+//	      ]
+//	    }
+//	  ]
+//	}
+//
+// The CSV export has one row per book with the same fields as columns;
+// its tags and descriptions columns hold them as JSON arrays.
+// cover_url is where the cover of the exported book is served, for as long as the book is not deleted;
+// the export does not carry the image itself, so an import ignores it.
+type LibraryExport struct {
+	Version    int           `json:"version"`
+	ExportedAt time.Time     `json:"exported_at"`
+	Books      ExportedBooks `json:"books"`
+}
+
+type ExportedBook struct {
+	Title         string               `json:"title"`
+	Author        string               `json:"author"`
+	Status        string               `json:"status"`
+	ReadCount     uint                 `json:"read_count"`
+	StartAt       NullTime             `json:"start_at"`
+	EndAt         NullTime             `json:"end_at"`
+	Rating        NullFloat64          `json:"rating"`
+	Review        string               `json:"review"`
+	ISBN          string               `json:"isbn"`
+	ISBN13        string               `json:"isbn13"`
+	TotalPages    uint                 `json:"total_pages"`
+	Publisher     string               `json:"publisher"`
+	PublishedDate string               `json:"published_date"`
+	CoverURL      string               `json:"cover_url"`
+	Tags          []string             `json:"tags"`
+	Descriptions  ExportedDescriptions `json:"descriptions"`
+}
+
+type ExportedBooks []ExportedBook
+
+// ExportedDescription is a description with the times it was written and last edited.
+// SourceKey is set on descriptions imported from elsewhere, such as Kindle clippings,
+// so that importing the same source again after a restore still skips them.
+type ExportedDescription struct {
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	EditedAt  NullTime  `json:"edited_at"`
+	SourceKey string    `json:"source_key,omitempty"`
+}
+
+// UnmarshalJSON also reads a description of version 1, a plain string, which leaves the times unset.
+func (d *ExportedDescription) UnmarshalJSON(data []byte) error {
+	var content string
+	if json.Unmarshal(data, &content) == nil {
+		*d = ExportedDescription{Content: content}
+		return nil
+	}
+	type exportedDescription ExportedDescription
+	return json.Unmarshal(data, (*exportedDescription)(d))
+}
+
+type ExportedDescriptions []ExportedDescription
+
+func NewExportedBook(book Book, descriptions Descriptions) ExportedBook {
+	e := ExportedBook{
+		Title:         book.Title,
+		Status:        book.ReadState.String(),
+		ReadCount:     book.ReadCount,
+		StartAt:       book.StartAt,
+		EndAt:         book.EndAt,
+		Rating:        book.Rating,
+		Review:        book.Review,
+		ISBN:          book.ISBN,
+		ISBN13:        book.ISBN13,
+		TotalPages:    book.TotalPages,
+		Publisher:     book.Publisher,
+		PublishedDate: book.PublishedDate,
+		CoverURL:      book.CoverURL,
+		Tags:          make([]string, 0, len(book.Tags)),
+		Descriptions:  make(ExportedDescriptions, 0, len(descriptions)),
+	}
+	if book.Author != nil {
+		e.Author = book.Author.Name
+	}
+	for _, v := range book.Tags {
+		e.Tags = append(e.Tags, v.Name)
+	}
+	for _, v := range descriptions {
+		e.Descriptions = append(e.Descriptions, ExportedDescription{
+			Content:   v.Content,
+			CreatedAt: v.CreatedAt,
+			EditedAt:  v.EditedAt,
+			SourceKey: v.SourceKey,
+		})
+	}
+	return e
+}
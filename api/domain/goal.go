@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Goal is the number of books an account means to finish in a calendar year.
+type Goal struct {
+	Base
+	AccountID string `json:"account_id"`
+	Year      int    `json:"year"`
+	Target    uint   `json:"target"`
+}
+
+func (Goal) TableName() string {
+	return "goal"
+}
+
+func (g *Goal) Validate() error {
+	if g.Year < 1 || g.Year > 9999 {
+		return ErrInvalidGoalYear
+	}
+	if g.Target == 0 {
+		return ErrInvalidGoalTarget
+	}
+	return nil
+}
+
+type Goals []Goal
+
+type GoalStatus string
+
+const (
+	CompletedGoal GoalStatus = "completed"
+	OnTrackGoal   GoalStatus = "on_track"
+	BehindGoal    GoalStatus = "behind"
+)
+
+// GoalProgress reports how a goal is going. Expected is how many books should be finished
+// by now to reach the target at an even pace, and Projected the year-end total at the current pace.
+type GoalProgress struct {
+	Goal      Goal       `json:"goal"`
+	Finished  int64      `json:"finished"`
+	Expected  float64    `json:"expected"`
+	Projected int64      `json:"projected"`
+	Status    GoalStatus `json:"status"`
+}
+
+type GoalProgresses []GoalProgress
+
+// YearRange returns the start of the year and the start of the next one.
+func YearRange(year int) (time.Time, time.Time) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(1, 0, 0)
+}
+
+// NewGoalProgress measures finished books against the goal as of now.
+func NewGoalProgress(goal Goal, finished int64, now time.Time) GoalProgress {
+	start, end := YearRange(goal.Year)
+	elapsed := float64(now.Sub(start)) / float64(end.Sub(start))
+	elapsed = math.Max(0, math.Min(1, elapsed))
+
+	p := GoalProgress{Goal: goal, Finished: finished}
+	p.Expected = float64(goal.Target) * elapsed
+	p.Projected = finished
+	if elapsed > 0 {
+		p.Projected = int64(math.Round(float64(finished) / elapsed))
+	}
+	switch {
+	case finished >= int64(goal.Target):
+		p.Status = CompletedGoal
+	case float64(finished) >= math.Floor(p.Expected):
+		p.Status = OnTrackGoal
+	default:
+		p.Status = BehindGoal
+	}
+	return p
+}
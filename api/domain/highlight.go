@@ -0,0 +1,54 @@
+package domain
+
+// LocationKind is the unit a highlight's position is measured in:
+// printed pages, or e-reader locations.
+type LocationKind string
+
+const (
+	PageLocation     LocationKind = "page"
+	LocationLocation LocationKind = "location"
+)
+
+func (k LocationKind) IsValid() bool {
+	return k == PageLocation || k == LocationLocation
+}
+
+var highlightColors = map[string]bool{
+	"yellow": true,
+	"blue":   true,
+	"pink":   true,
+	"orange": true,
+	"green":  true,
+}
+
+// Highlight is a passage quoted from a book, with where it appears and an optional comment.
+// EndPosition equals StartPosition for a passage on a single page or location.
+type Highlight struct {
+	Base
+	BookId        uint64       `json:"book_id"`
+	Quote         string       `json:"quote"`
+	LocationKind  LocationKind `json:"location_kind"`
+	StartPosition uint         `json:"start_position"`
+	EndPosition   uint         `json:"end_position"`
+	Color         string       `json:"color"`
+	Comment       string       `json:"comment"`
+}
+
+func (Highlight) TableName() string {
+	return "highlight"
+}
+
+func (h *Highlight) Validate() error {
+	if h.Quote == "" {
+		return ErrEmptyQuote
+	}
+	if !h.LocationKind.IsValid() || h.StartPosition == 0 || h.EndPosition < h.StartPosition {
+		return ErrInvalidLocation
+	}
+	if h.Color != "" && !highlightColors[h.Color] {
+		return ErrInvalidColor
+	}
+	return nil
+}
+
+type Highlights []Highlight
@@ -0,0 +1,44 @@
+package domain
+
+type ImportResult string
+
+const (
+	CreatedImport ImportResult = "created"
+	SkippedImport ImportResult = "skipped"
+	FailedImport  ImportResult = "failed"
+)
+
+// ImportRow reports what became of one row of an import. Line is the line of a CSV row,
+// counting the header, or the position of a JSON entry, counting from 1.
+type ImportRow struct {
+	Line   int          `json:"line"`
+	Title  string       `json:"title"`
+	Result ImportResult `json:"result"`
+	BookID uint64       `json:"book_id,omitempty"`
+	Error  string       `json:"error,omitempty"`
+}
+
+type ImportRows []ImportRow
+
+type ImportReport struct {
+	Created int        `json:"created"`
+	Skipped int        `json:"skipped"`
+	Failed  int        `json:"failed"`
+	Rows    ImportRows `json:"rows"`
+}
+
+func NewImportReport() ImportReport {
+	return ImportReport{Rows: ImportRows{}}
+}
+
+func (r *ImportReport) Add(row ImportRow) {
+	switch row.Result {
+	case CreatedImport:
+		r.Created++
+	case SkippedImport:
+		r.Skipped++
+	case FailedImport:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, row)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+type JobStatus string
+
+const (
+	QueuedJob   JobStatus = "queued"
+	RunningJob  JobStatus = "running"
+	FinishedJob JobStatus = "finished"
+	FailedJob   JobStatus = "failed"
+)
+
+// ImportJob is an import running in the background. Report holds the rows processed so far.
+type ImportJob struct {
+	Base
+	AccountID  string       `json:"account_id"`
+	Source     string       `json:"source"`
+	Status     JobStatus    `json:"status"`
+	Total      int          `json:"total"`
+	Processed  int          `json:"processed"`
+	Report     ImportReport `json:"report"`
+	Error      string       `json:"error,omitempty"`
+	FinishedAt NullTime     `json:"finished_at"`
+}
+
+type ImportJobs []ImportJob
+
+func NewImportJob(accountId string, source string, total int) ImportJob {
+	j := ImportJob{}
+	j.AccountID = accountId
+	j.Source = source
+	j.Status = QueuedJob
+	j.Total = total
+	j.Report = NewImportReport()
+	j.FinishedAt = NullTime{mysql.NullTime{Valid: false}}
+	j.CreatedAt = time.Now()
+	j.UpdatedAt = time.Now()
+	return j
+}
+
+// Finish ends the job, as failed when err is not nil.
+func (j *ImportJob) Finish(err error) {
+	j.Status = FinishedJob
+	if err != nil {
+		j.Status = FailedJob
+		j.Error = err.Error()
+	}
+	j.FinishedAt = NullTime{mysql.NullTime{Time: time.Now(), Valid: true}}
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "strings"
+
+var isbnSeparators = strings.NewReplacer("-", "", " ", "")
+
+// NormalizeISBN drops the hyphens and spaces ISBNs are usually printed with and upper-cases the X check digit.
+func NormalizeISBN(isbn string) string {
+	return strings.ToUpper(isbnSeparators.Replace(strings.TrimSpace(isbn)))
+}
+
+// ValidISBN10 checks the length, digits and mod 11 check digit of a normalized ISBN-10.
+func ValidISBN10(isbn string) bool {
+	if len(isbn) != 10 {
+		return false
+	}
+	sum := 0
+	for i, r := range isbn {
+		var digit int
+		switch {
+		case r >= '0' && r <= '9':
+			digit = int(r - '0')
+		case r == 'X' && i == 9:
+			digit = 10
+		default:
+			return false
+		}
+		sum += digit * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+// ValidISBN13 checks the length, digits, 978/979 prefix and mod 10 check digit of a normalized ISBN-13.
+func ValidISBN13(isbn string) bool {
+	if len(isbn) != 13 || !(strings.HasPrefix(isbn, "978") || strings.HasPrefix(isbn, "979")) {
+		return false
+	}
+	sum := 0
+	for i, r := range isbn {
+		if r < '0' || r > '9' {
+			return false
+		}
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(r-'0') * weight
+	}
+	return sum%10 == 0
+}
+
+// ISBN10To13 converts a valid ISBN-10 to the ISBN-13 of the same edition.
+func ISBN10To13(isbn string) string {
+	isbn13 := "978" + isbn[:9]
+	sum := 0
+	for i, r := range isbn13 {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(r-'0') * weight
+	}
+	return isbn13 + string(rune('0'+(10-sum%10)%10))
+}
+
+// ValidateISBN accepts empty ISBNs and normalized ones with a correct check digit.
+func ValidateISBN(isbn string, isbn13 string) error {
+	if isbn != "" && !ValidISBN10(isbn) {
+		return ErrInvalidISBN10
+	}
+	if isbn13 != "" && !ValidISBN13(isbn13) {
+		return ErrInvalidISBN13
+	}
+	return nil
+}
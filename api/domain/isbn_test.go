@@ -0,0 +1,95 @@
+package domain
+
+import "testing"
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := map[string]string{
+		"0-441-01359-7":       "0441013597",
+		" 978 0 441 01359 3 ": "9780441013593",
+		"0-8044-2957-x":       "080442957X",
+	}
+	for in, want := range tests {
+		if got := NormalizeISBN(in); got != want {
+			t.Errorf("NormalizeISBN(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestValidISBN10(t *testing.T) {
+	tests := []struct {
+		isbn string
+		want bool
+	}{
+		{"0441013597", true},
+		{"080442957X", true},
+		{"0306406152", true},
+		{"0441013598", false}, // wrong check digit
+		{"044101359X", false},
+		{"X441013597", false}, // X only stands for 10 as the check digit
+		{"044101359", false},
+		{"04410135970", false},
+		{"04410A3597", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidISBN10(tt.isbn); got != tt.want {
+			t.Errorf("ValidISBN10(%q) = %v, want %v", tt.isbn, got, tt.want)
+		}
+	}
+}
+
+func TestValidISBN13(t *testing.T) {
+	tests := []struct {
+		isbn string
+		want bool
+	}{
+		{"9780441013593", true},
+		{"9780306406157", true},
+		{"9791090636071", true},
+		{"9780441013594", false}, // wrong check digit
+		{"9770441013594", false}, // right check digit, but not a 978 or 979 prefix
+		{"978044101359", false},
+		{"97804410135930", false},
+		{"978044101359X", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidISBN13(tt.isbn); got != tt.want {
+			t.Errorf("ValidISBN13(%q) = %v, want %v", tt.isbn, got, tt.want)
+		}
+	}
+}
+
+func TestISBN10To13(t *testing.T) {
+	tests := map[string]string{
+		"0441013597": "9780441013593",
+		"0306406152": "9780306406157",
+		"080442957X": "9780804429573",
+	}
+	for in, want := range tests {
+		if got := ISBN10To13(in); got != want {
+			t.Errorf("ISBN10To13(%q) = %q, want %q", in, got, want)
+		}
+		if !ValidISBN13(ISBN10To13(in)) {
+			t.Errorf("ISBN10To13(%q) is not a valid ISBN-13", in)
+		}
+	}
+}
+
+func TestValidateISBN(t *testing.T) {
+	tests := []struct {
+		isbn, isbn13 string
+		want         error
+	}{
+		{"", "", nil},
+		{"0441013597", "9780441013593", nil},
+		{"0441013598", "", ErrInvalidISBN10},
+		{"", "9780441013594", ErrInvalidISBN13},
+		{"0441013597", "0441013597", ErrInvalidISBN13},
+	}
+	for _, tt := range tests {
+		if got := ValidateISBN(tt.isbn, tt.isbn13); got != tt.want {
+			t.Errorf("ValidateISBN(%q, %q) = %v, want %v", tt.isbn, tt.isbn13, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+// BookMetadata describes an edition as a metadata provider knows it.
+type BookMetadata struct {
+	ISBN          string `json:"isbn"`
+	ISBN13        string `json:"isbn13"`
+	Title         string `json:"title"`
+	Author        string `json:"author"`
+	Publisher     string `json:"publisher"`
+	PublishedDate string `json:"published_date"`
+	TotalPages    uint   `json:"total_pages"`
+}
+
+// Fill copies the metadata into the fields of the book that are still empty, so what the user entered wins.
+func (m BookMetadata) Fill(book *Book) {
+	if book.Title == "" {
+		book.Title = m.Title
+	}
+	if book.Author == nil && m.Author != "" {
+		book.Author = &Author{Name: m.Author}
+	}
+	if book.ISBN == "" {
+		book.ISBN = m.ISBN
+	}
+	if book.ISBN13 == "" {
+		book.ISBN13 = m.ISBN13
+	}
+	if book.Publisher == "" {
+		book.Publisher = m.Publisher
+	}
+	if book.PublishedDate == "" {
+		book.PublishedDate = m.PublishedDate
+	}
+	if book.TotalPages == 0 {
+		book.TotalPages = m.TotalPages
+	}
+}
@@ -32,10 +32,40 @@ func (i *NullInt64) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type NullFloat64 struct {
+	sql.NullFloat64
+}
+func NewNullFloat(f float64) NullFloat64 {
+	return NullFloat64{NullFloat64: sql.NullFloat64{Float64: f, Valid: true}}
+}
+func (f NullFloat64) MarshalJSON() ([]byte, error) {
+	if f.Valid {
+		return json.Marshal(f.Float64)
+	} else {
+		return nullLiteral, nil
+	}
+}
+func (f *NullFloat64) UnmarshalJSON(data []byte) error {
+	if bytes.Compare(data, nullLiteral) == 0 {
+		f.Valid = false
+		return nil
+	}
+	var num float64
+	if err := json.Unmarshal(data, &num); err != nil {
+		return err
+	}
+	f.Float64 = num
+	f.Valid = true
+	return nil
+}
+
 
 type NullTime struct {
 	mysql.NullTime
 }
+func NewNullTime(t time.Time) NullTime {
+	return NullTime{NullTime: mysql.NullTime{Time: t, Valid: true}}
+}
 func (nt NullTime) MarshalJSON() ([]byte, error) {
 	if nt.Valid {
 		return nt.Time.MarshalJSON()
@@ -44,18 +74,18 @@ func (nt NullTime) MarshalJSON() ([]byte, error) {
 	}
 }
 func (nt *NullTime) UnmarshalJSON(data []byte) error {
-	if bytes.Compare(data, []byte("null")) == 0 {
+	if bytes.Compare(data, nullLiteral) == 0 {
 		nt.Valid = false
 		return nil
 	}
-	now := time.Now()
-	err := nt.UnmarshalJSON(data)
+	var t time.Time
+	err := json.Unmarshal(data, &t)
 
 	if err != nil {
 		return err
 	}
 	nt.Valid = true
-	nt.Time = now
+	nt.Time = t
 
 	return nil
 }
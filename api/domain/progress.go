@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// PaceWindow is how far back from the latest progress entry the reading pace is measured.
+const PaceWindow = 14 * 24 * time.Hour
+
+// ProgressEntry records how far into a book the reader was at a point in time.
+// Percent is always set; Page is 0 when the entry was logged as a percentage
+// of a book without a page count.
+type ProgressEntry struct {
+	Base
+	BookId     uint64    `json:"book_id"`
+	Page       uint      `json:"page"`
+	Percent    float64   `json:"percent"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+func (ProgressEntry) TableName() string {
+	return "reading_progress"
+}
+
+type ProgressEntries []ProgressEntry
+
+// NewProgressEntry logs progress on book either by page or by percent, exactly one of which must be given.
+// Pages need the book's total_pages to be known so that they can be turned into a percentage.
+func NewProgressEntry(book Book, page *uint, percent *float64, at time.Time) (*ProgressEntry, error) {
+	if (page == nil) == (percent == nil) {
+		return nil, ErrInvalidProgress
+	}
+	if at.After(time.Now().Add(maxClockSkew)) {
+		return nil, ErrFutureReadDate
+	}
+
+	p := ProgressEntry{BookId: book.ID, RecordedAt: at}
+	if page != nil {
+		if book.TotalPages == 0 {
+			return nil, ErrMissingTotalPages
+		}
+		if *page > book.TotalPages {
+			return nil, ErrInvalidProgress
+		}
+		p.Page = *page
+		p.Percent = float64(*page) * 100 / float64(book.TotalPages)
+	} else {
+		if *percent < 0 || *percent > 100 {
+			return nil, ErrInvalidProgress
+		}
+		p.Percent = *percent
+		p.Page = uint(math.Round(*percent * float64(book.TotalPages) / 100))
+	}
+	p.CreatedAt = time.Now()
+	p.UpdatedAt = time.Now()
+	return &p, nil
+}
+
+// BookProgress is the current progress of a book as shown with the book.
+type BookProgress struct {
+	Page              uint      `json:"page"`
+	Percent           float64   `json:"percent"`
+	RecordedAt        time.Time `json:"recorded_at"`
+	EstimatedFinishAt NullTime  `json:"estimated_finish_at"`
+}
+
+// CurrentProgress returns the latest of entries, which must be ordered by RecordedAt, or nil when there are none.
+// The finish date is extrapolated from the pace between the latest entry and the earliest one within PaceWindow of it,
+// and is left null while the book is finished or the pace cannot be told.
+func (entries ProgressEntries) CurrentProgress() *BookProgress {
+	if len(entries) == 0 {
+		return nil
+	}
+	latest := entries[len(entries)-1]
+	progress := BookProgress{
+		Page:              latest.Page,
+		Percent:           latest.Percent,
+		RecordedAt:        latest.RecordedAt,
+		EstimatedFinishAt: NullTime{mysql.NullTime{Valid: false}},
+	}
+	if latest.Percent >= 100 {
+		return &progress
+	}
+
+	since := latest.RecordedAt.Add(-PaceWindow)
+	first := latest
+	for _, v := range entries {
+		if !v.RecordedAt.Before(since) {
+			first = v
+			break
+		}
+	}
+	elapsed := latest.RecordedAt.Sub(first.RecordedAt)
+	read := latest.Percent - first.Percent
+	if elapsed > 0 && read > 0 {
+		remaining := time.Duration((100 - latest.Percent) / read * float64(elapsed))
+		progress.EstimatedFinishAt = NullTime{mysql.NullTime{Time: latest.RecordedAt.Add(remaining), Valid: true}}
+	}
+	return &progress
+}
@@ -0,0 +1,24 @@
+package domain
+
+// RatingStat summarises the ratings of the books sharing an author or a tag.
+type RatingStat struct {
+	ID      uint64  `json:"id"`
+	Name    string  `json:"name"`
+	Count   int64   `json:"count"`
+	Average float64 `json:"average"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+}
+
+type RatingStats []RatingStat
+
+func (s *RatingStat) Add(rating float64) {
+	if s.Count == 0 || rating < s.Min {
+		s.Min = rating
+	}
+	if s.Count == 0 || rating > s.Max {
+		s.Max = rating
+	}
+	s.Average = (s.Average*float64(s.Count) + rating) / float64(s.Count+1)
+	s.Count++
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+type ReadTransition string
+
+const (
+	StartTransition   ReadTransition = "start"
+	FinishTransition  ReadTransition = "finish"
+	AbandonTransition ReadTransition = "abandon"
+	ResetTransition   ReadTransition = "reset"
+)
+
+// maxClockSkew tolerates client clocks running slightly ahead of the server.
+const maxClockSkew = time.Minute
+
+// readTransitions maps each transition to the states it may start from and the state it leads to.
+var readTransitions = map[ReadTransition]map[ReadState]ReadState{
+	StartTransition: {
+		NotReadValue:   ReadingValue,
+		ReadValue:      ReadingValue,
+		AbandonedValue: ReadingValue,
+	},
+	FinishTransition: {
+		ReadingValue: ReadValue,
+	},
+	AbandonTransition: {
+		ReadingValue: AbandonedValue,
+	},
+	ResetTransition: {
+		ReadingValue:   NotReadValue,
+		ReadValue:      NotReadValue,
+		AbandonedValue: NotReadValue,
+	},
+}
+
+// Transition moves the book to the state reached by t, stamping the read dates with at.
+// It returns ErrInvalidTransition when t is not allowed from the current state.
+func (b *Book) Transition(t ReadTransition, at time.Time) error {
+	next, ok := readTransitions[t][b.ReadState]
+	if !ok {
+		return ErrInvalidTransition
+	}
+	if at.After(time.Now().Add(maxClockSkew)) {
+		return ErrFutureReadDate
+	}
+
+	switch t {
+	case StartTransition:
+		b.StartAt = NullTime{mysql.NullTime{Time: at, Valid: true}}
+		b.EndAt = NullTime{mysql.NullTime{Valid: false}}
+	case FinishTransition, AbandonTransition:
+		if at.Before(b.StartAt.Time) {
+			return ErrReadDateOrder
+		}
+		b.EndAt = NullTime{mysql.NullTime{Time: at, Valid: true}}
+		if t == FinishTransition {
+			b.ReadCount++
+		}
+	case ResetTransition:
+		b.StartAt = NullTime{mysql.NullTime{Valid: false}}
+		b.EndAt = NullTime{mysql.NullTime{Valid: false}}
+	}
+	b.ReadState = next
+	return nil
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+type SessionOutcome string
+
+const (
+	InProgressOutcome SessionOutcome = "in_progress"
+	FinishedOutcome   SessionOutcome = "finished"
+	AbandonedOutcome  SessionOutcome = "abandoned"
+)
+
+// ReadingSession is one read-through of a book, from starting it until finishing or giving up.
+type ReadingSession struct {
+	Base
+	BookId     uint64         `json:"book_id"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt NullTime       `json:"finished_at"`
+	Outcome    SessionOutcome `json:"outcome"`
+}
+
+func (ReadingSession) TableName() string {
+	return "reading_session"
+}
+
+type ReadingSessions []ReadingSession
+
+func NewReadingSession(bookId uint64, startedAt time.Time) ReadingSession {
+	s := ReadingSession{}
+	s.BookId = bookId
+	s.StartedAt = startedAt
+	s.FinishedAt = NullTime{mysql.NullTime{Valid: false}}
+	s.Outcome = InProgressOutcome
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+	return s
+}
+
+func (s *ReadingSession) Close(outcome SessionOutcome, at time.Time) {
+	s.FinishedAt = NullTime{mysql.NullTime{Time: at, Valid: true}}
+	s.Outcome = outcome
+	s.UpdatedAt = time.Now()
+}
+
+// SessionOutcome reports how a transition ends the open reading session.
+// Starting a book opens a new session instead, so it reports InProgressOutcome.
+func (t ReadTransition) SessionOutcome() SessionOutcome {
+	switch t {
+	case FinishTransition:
+		return FinishedOutcome
+	case AbandonTransition, ResetTransition:
+		return AbandonedOutcome
+	default:
+		return InProgressOutcome
+	}
+}
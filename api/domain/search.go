@@ -0,0 +1,29 @@
+package domain
+
+type SearchHitType string
+
+const (
+	BookHitType        SearchHitType = "book"
+	DescriptionHitType SearchHitType = "description"
+)
+
+// Snippet is an excerpt of a matched field, with the matches wrapped in <em> and the rest HTML escaped.
+type Snippet struct {
+	Field string `json:"field"`
+	Text  string `json:"text"`
+}
+
+type SearchHit struct {
+	Type        SearchHitType `json:"type"`
+	Score       float64       `json:"score"`
+	Book        *Book         `json:"book"`
+	Description *Description  `json:"description,omitempty"`
+	Snippets    []Snippet     `json:"snippets"`
+}
+
+type SearchHits []SearchHit
+
+type PaginateSearchHits struct {
+	Hits       SearchHits `json:"hits"`
+	TotalCount int64      `json:"total_count"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+// Series is an ordered run of books, such as the volumes of a novel cycle.
+type Series struct {
+	Base
+	AccountID string `json:"account_id"`
+	Title     string `json:"title"`
+	Books     Books  `json:"books" gorm:"-"`
+}
+
+func (Series) TableName() string {
+	return "series"
+}
+
+func (s *Series) Validate() error {
+	if s.Title == "" {
+		return ErrEmptySeriesTitle
+	}
+	return nil
+}
+
+type SeriesList []Series
+
+// SeriesBook places a book in a series. A book belongs to at most one series,
+// and books are read in ascending Position.
+type SeriesBook struct {
+	SeriesId uint64 `gorm:"primary_key;auto_increment:false"`
+	BookId   uint64 `gorm:"primary_key;auto_increment:false"`
+	Position uint
+}
+
+func (SeriesBook) TableName() string {
+	return "series_book"
+}
+
+type SeriesBooks []SeriesBook
+
+// BookSeries is the series a book belongs to, as shown with the book.
+// Position counts from 1; Prev and Next are the neighbouring volumes, if any.
+type BookSeries struct {
+	ID       uint64 `json:"id"`
+	Title    string `json:"title"`
+	Position int    `json:"position"`
+	Prev     *Book  `json:"prev"`
+	Next     *Book  `json:"next"`
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// PeriodStat counts the books finished in a month ("2006-01") or a year ("2006")
+// and the total pages of those books, wherever in time the pages were read.
+type PeriodStat struct {
+	Period        string `json:"period"`
+	Books         int64  `json:"books"`
+	FinishedPages int64  `json:"finished_pages"`
+}
+
+type PeriodStats []PeriodStat
+
+// RankedStat counts the books finished per author or tag.
+type RankedStat struct {
+	ID    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type RankedStats []RankedStat
+
+// ReadingPeriod is the span a book was read over. EndAt is null while the book is still being read.
+type ReadingPeriod struct {
+	StartAt   time.Time
+	EndAt     NullTime
+	ReadState ReadState
+}
+
+type ReadingPeriods []ReadingPeriod
+
+// Streak is a run of consecutive days on which at least one book was being read.
+type Streak struct {
+	Days    int      `json:"days"`
+	StartAt NullTime `json:"start_at"`
+	EndAt   NullTime `json:"end_at"`
+}
+
+type ReadingStats struct {
+	FinishedPerMonth    PeriodStats `json:"finished_per_month"`
+	FinishedPerYear     PeriodStats `json:"finished_per_year"`
+	AverageDaysToFinish NullFloat64 `json:"average_days_to_finish"`
+	CurrentlyReading    int64       `json:"currently_reading"`
+	TopAuthors          RankedStats `json:"top_authors"`
+	TopTags             RankedStats `json:"top_tags"`
+	LongestStreak       Streak      `json:"longest_streak"`
+}
+
+// AverageDaysToFinish is the mean number of days from start to end over the finished books,
+// or null when none has both dates. Books dated as ending before they started are left out.
+func (periods ReadingPeriods) AverageDaysToFinish() NullFloat64 {
+	var total float64
+	var count int
+	for _, v := range periods {
+		if v.ReadState != ReadValue || !v.EndAt.Valid || v.EndAt.Time.Before(v.StartAt) {
+			continue
+		}
+		total += v.EndAt.Time.Sub(v.StartAt).Hours() / 24
+		count++
+	}
+	if count == 0 {
+		return NullFloat64{}
+	}
+	return NewNullFloat(total / float64(count))
+}
+
+// LongestStreak merges the periods into runs of calendar days and returns the longest run.
+// Periods still open are counted up to now.
+func (periods ReadingPeriods) LongestStreak(now time.Time) Streak {
+	spans := make([][2]time.Time, 0, len(periods))
+	for _, v := range periods {
+		end := now
+		if v.EndAt.Valid {
+			end = v.EndAt.Time
+		}
+		if end.Before(v.StartAt) {
+			continue
+		}
+		spans = append(spans, [2]time.Time{truncateDay(v.StartAt), truncateDay(end)})
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i][0].Before(spans[j][0])
+	})
+
+	streak := Streak{}
+	var start, end time.Time
+	for i, v := range spans {
+		if i > 0 && !v[0].After(end.AddDate(0, 0, 1)) {
+			if v[1].After(end) {
+				end = v[1]
+			}
+		} else {
+			start, end = v[0], v[1]
+		}
+		days := daysBetween(start, end) + 1
+		if days > streak.Days {
+			streak.Days = days
+			streak.StartAt = NullTime{mysql.NullTime{Time: start, Valid: true}}
+			streak.EndAt = NullTime{mysql.NullTime{Time: end, Valid: true}}
+		}
+	}
+	return streak
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween counts calendar days between two midnights, ignoring daylight saving shifts.
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+}
+
+func finished(start, end time.Time) ReadingPeriod {
+	return ReadingPeriod{StartAt: start, EndAt: NewNullTime(end), ReadState: ReadValue}
+}
+
+func TestLongestStreak(t *testing.T) {
+	now := day(20).Add(15 * time.Hour)
+	tests := []struct {
+		name       string
+		periods    ReadingPeriods
+		days       int
+		start, end time.Time
+	}{
+		{name: "none", periods: ReadingPeriods{}},
+		{name: "single day", periods: ReadingPeriods{finished(day(1).Add(8*time.Hour), day(1).Add(22*time.Hour))},
+			days: 1, start: day(1), end: day(1)},
+		{name: "overlapping", periods: ReadingPeriods{finished(day(1), day(5)), finished(day(3), day(8))},
+			days: 8, start: day(1), end: day(8)},
+		{name: "contained", periods: ReadingPeriods{finished(day(1), day(10)), finished(day(3), day(4))},
+			days: 10, start: day(1), end: day(10)},
+		{name: "adjacent days", periods: ReadingPeriods{finished(day(1), day(3)), finished(day(4), day(6))},
+			days: 6, start: day(1), end: day(6)},
+		{name: "disjoint", periods: ReadingPeriods{finished(day(1), day(3)), finished(day(5), day(6))},
+			days: 3, start: day(1), end: day(3)},
+		{name: "longest last", periods: ReadingPeriods{finished(day(10), day(15)), finished(day(1), day(2))},
+			days: 6, start: day(10), end: day(15)},
+		{name: "open counted up to now", periods: ReadingPeriods{
+			{StartAt: day(17).Add(20 * time.Hour), ReadState: ReadingValue}, finished(day(1), day(2))},
+			days: 4, start: day(17), end: day(20)},
+		{name: "open bridging to a finished period", periods: ReadingPeriods{
+			finished(day(10), day(16)), {StartAt: day(17), ReadState: ReadingValue}},
+			days: 11, start: day(10), end: day(20)},
+		{name: "end before start ignored", periods: ReadingPeriods{finished(day(9), day(2)), finished(day(4), day(5))},
+			days: 2, start: day(4), end: day(5)},
+		{name: "open starting after now ignored", periods: ReadingPeriods{{StartAt: day(25), ReadState: ReadingValue}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.periods.LongestStreak(now)
+			if got.Days != tt.days {
+				t.Fatalf("got %d days, want %d", got.Days, tt.days)
+			}
+			if tt.days == 0 {
+				if got.StartAt.Valid || got.EndAt.Valid {
+					t.Errorf("got %+v, want no dates", got)
+				}
+				return
+			}
+			if !got.StartAt.Time.Equal(tt.start) || !got.EndAt.Time.Equal(tt.end) {
+				t.Errorf("got %v to %v, want %v to %v", got.StartAt.Time, got.EndAt.Time, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestAverageDaysToFinish(t *testing.T) {
+	tests := []struct {
+		name    string
+		periods ReadingPeriods
+		want    NullFloat64
+	}{
+		{name: "none", periods: ReadingPeriods{}, want: NullFloat64{}},
+		{name: "finished books", periods: ReadingPeriods{finished(day(1), day(3)), finished(day(1), day(7))},
+			want: NewNullFloat(4)},
+		{name: "part of a day", periods: ReadingPeriods{finished(day(1), day(1).Add(12*time.Hour))}, want: NewNullFloat(0.5)},
+		{name: "reading and abandoned books left out", periods: ReadingPeriods{
+			finished(day(1), day(3)),
+			{StartAt: day(1), ReadState: ReadingValue},
+			{StartAt: day(1), EndAt: NewNullTime(day(20)), ReadState: AbandonedValue},
+		}, want: NewNullFloat(2)},
+		{name: "read without an end date left out", periods: ReadingPeriods{{StartAt: day(1), ReadState: ReadValue}},
+			want: NullFloat64{}},
+		{name: "end before start left out", periods: ReadingPeriods{finished(day(5), day(3)), finished(day(1), day(7))},
+			want: NewNullFloat(6)},
+		{name: "only end before start", periods: ReadingPeriods{finished(day(5), day(3))}, want: NullFloat64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.periods.AverageDaysToFinish(); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
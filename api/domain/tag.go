@@ -0,0 +1,34 @@
+package domain
+
+// Tag is a per-account label put on books.
+type Tag struct {
+	Base
+	AccountID string `json:"account_id"`
+	Name      string `json:"name"`
+	BookCount int64  `json:"book_count" gorm:"-"`
+}
+
+func (Tag) TableName() string {
+	return "tag"
+}
+
+func (t *Tag) Validate() error {
+	if t.Name == "" {
+		return ErrEmptyTagName
+	}
+	return nil
+}
+
+type Tags []Tag
+
+// BookTag links a book to one of its tags.
+type BookTag struct {
+	BookId uint64 `gorm:"primary_key;auto_increment:false"`
+	TagId  uint64 `gorm:"primary_key;auto_increment:false"`
+}
+
+func (BookTag) TableName() string {
+	return "book_tag"
+}
+
+type BookTags []BookTag
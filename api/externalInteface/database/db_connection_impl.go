@@ -6,6 +6,7 @@ import (
 	"github.com/jinzhu/gorm"
 
 	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
 )
 
 type dbConnection struct {
@@ -17,15 +18,23 @@ func (conn *dbConnection) Bind(bind interface{}) repositories.DBConnection {
 }
 
 func (conn *dbConnection) Paginate(page, perPage uint64) repositories.DBConnection {
+	if page < 1 {
+		page = 1
+	}
 	return &dbConnection{DB: conn.DB.Offset(perPage * (page - 1)).Limit(perPage)}
 }
 
-func (conn *dbConnection) Select(filter interface{}) repositories.DBConnection {
-	return &dbConnection{DB: conn.DB.Where(filter)}
-}
-
-func (conn *dbConnection) OrFilter(filter interface{}) repositories.DBConnection {
-	return &dbConnection{DB: conn.DB.Or(filter)}
+func (conn *dbConnection) Select(filter *usecases.Filter) repositories.DBConnection {
+	query, args, err := buildFilter(filter)
+	if err != nil {
+		db := conn.DB.New()
+		db.AddError(err)
+		return &dbConnection{DB: db}
+	}
+	if query == "" {
+		return conn
+	}
+	return &dbConnection{DB: conn.DB.Where(query, args...)}
 }
 
 func (conn *dbConnection) Create(data interface{}) repositories.DBConnection {
@@ -36,16 +45,20 @@ func (conn *dbConnection) Update(data interface{}) repositories.DBConnection {
 	return &dbConnection{DB: conn.DB.Save(data)}
 }
 
+func (conn *dbConnection) Updates(values interface{}) repositories.DBConnection {
+	return &dbConnection{DB: conn.DB.Updates(values)}
+}
+
 func (conn *dbConnection) Delete(data interface{}) repositories.DBConnection {
 	return &dbConnection{DB: conn.DB.Delete(data)}
 }
 
 func (conn *dbConnection) SortDesc(key string) repositories.DBConnection {
-	return &dbConnection{DB: conn.DB.Order(fmt.Sprintf("%s desc", key), true)}
+	return &dbConnection{DB: conn.DB.Order(fmt.Sprintf("%s desc", key))}
 }
 
 func (conn *dbConnection) SortAsc(key string) repositories.DBConnection {
-	return &dbConnection{DB: conn.DB.Order(fmt.Sprintf("%s asc", key), true)}
+	return &dbConnection{DB: conn.DB.Order(fmt.Sprintf("%s asc", key))}
 }
 
 func (conn *dbConnection) Count(count *int64) repositories.DBConnection {
@@ -56,10 +69,46 @@ func (conn *dbConnection) Table(table interface{}) repositories.DBConnection {
 	return &dbConnection{DB: conn.DB.Model(table)}
 }
 
+func (conn *dbConnection) Columns(columns string, args ...interface{}) repositories.DBConnection {
+	return &dbConnection{DB: conn.DB.Select(columns, args...)}
+}
+
+func (conn *dbConnection) Group(key string) repositories.DBConnection {
+	return &dbConnection{DB: conn.DB.Group(key)}
+}
+
+func (conn *dbConnection) Join(join string, args ...interface{}) repositories.DBConnection {
+	return &dbConnection{DB: conn.DB.Joins(join, args...)}
+}
+
+func (conn *dbConnection) Scan(dest interface{}) repositories.DBConnection {
+	return &dbConnection{DB: conn.DB.Scan(dest)}
+}
+
+func (conn *dbConnection) Raw(sql string, args ...interface{}) repositories.DBConnection {
+	return &dbConnection{DB: conn.DB.Raw(sql, args...)}
+}
+
+func (conn *dbConnection) Transaction(fn func(tx repositories.DBConnection) error) error {
+	tx := conn.DB.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if err := fn(&dbConnection{DB: tx}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
 func (conn *dbConnection) HasError() error {
 	return conn.DB.Error
 }
 
+func (conn *dbConnection) RecordNotFound() bool {
+	return conn.DB.RecordNotFound()
+}
+
 func NewSqlConnection() dbConnection {
 	config, err := LoadConfig()
 	if err != nil {
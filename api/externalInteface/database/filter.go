@@ -0,0 +1,77 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+var columnPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*(\.[a-z_][a-z0-9_]*)?$`)
+
+// buildFilter translates a usecases.Filter into a WHERE clause and its bind arguments.
+// Field names end up in the SQL text, so anything that is not a plain column name is rejected.
+func buildFilter(filter *usecases.Filter) (string, []interface{}, error) {
+	if filter == nil {
+		return "", nil, nil
+	}
+
+	parts := make([]string, 0, len(filter.Conditions)+len(filter.Groups))
+	args := make([]interface{}, 0)
+	for _, c := range filter.Conditions {
+		if !columnPattern.MatchString(string(c.Field)) {
+			return "", nil, fmt.Errorf("buildFilter: invalid field %q", c.Field)
+		}
+		switch c.Operator {
+		case usecases.EqOperator, usecases.NotEqOperator:
+			if c.Value == nil {
+				op := usecases.IsNullOperator
+				if c.Operator == usecases.NotEqOperator {
+					op = usecases.NotNullOperator
+				}
+				parts = append(parts, fmt.Sprintf("%s %s", c.Field, op))
+				continue
+			}
+			parts = append(parts, fmt.Sprintf("%s %s ?", c.Field, c.Operator))
+			args = append(args, c.Value)
+		case usecases.GtOperator, usecases.GteOperator, usecases.LtOperator, usecases.LteOperator, usecases.LikeOperator:
+			parts = append(parts, fmt.Sprintf("%s %s ?", c.Field, c.Operator))
+			args = append(args, c.Value)
+		case usecases.InOperator:
+			v := reflect.ValueOf(c.Value)
+			if v.Kind() != reflect.Slice {
+				return "", nil, fmt.Errorf("buildFilter: IN on %q needs a slice", c.Field)
+			}
+			if v.Len() == 0 {
+				parts = append(parts, "1 = 0")
+				continue
+			}
+			parts = append(parts, fmt.Sprintf("%s IN (?)", c.Field))
+			args = append(args, c.Value)
+		case usecases.IsNullOperator, usecases.NotNullOperator:
+			parts = append(parts, fmt.Sprintf("%s %s", c.Field, c.Operator))
+		default:
+			return "", nil, fmt.Errorf("buildFilter: unknown operator %q", c.Operator)
+		}
+	}
+
+	for _, g := range filter.Groups {
+		query, groupArgs, err := buildFilter(g)
+		if err != nil {
+			return "", nil, err
+		}
+		if query == "" {
+			continue
+		}
+		parts = append(parts, "("+query+")")
+		args = append(args, groupArgs...)
+	}
+
+	conjunction := " AND "
+	if filter.Conjunction == usecases.OrConjunction {
+		conjunction = " OR "
+	}
+	return strings.Join(parts, conjunction), args, nil
+}
@@ -0,0 +1,46 @@
+package metadata
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+type Config struct {
+	Provider       string        `envconfig:"metadata_provider" default:"openlibrary"`
+	OpenLibraryURL string        `envconfig:"openlibrary_url" default:"https://openlibrary.org"`
+	Fixtures       string        `envconfig:"metadata_fixtures"`
+	Timeout        time.Duration `envconfig:"metadata_timeout" default:"10s"`
+}
+
+func LoadConfig() (*Config, error) {
+	var config = Config{}
+	if err := envconfig.Process("APP", &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// NewProvider returns the provider APP_METADATA_PROVIDER names: openlibrary, or fixture
+// to answer from the JSON file at APP_METADATA_FIXTURES without going online.
+func NewProvider() usecases.BookMetadataProvider {
+	config, err := LoadConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	switch config.Provider {
+	case "openlibrary":
+		return NewOpenLibraryProvider(config.OpenLibraryURL, config.Timeout)
+	case "fixture":
+		provider, err := LoadFixtureProvider(config.Fixtures)
+		if err != nil {
+			panic(err)
+		}
+		return provider
+	default:
+		panic(fmt.Errorf("unknown metadata provider %q", config.Provider))
+	}
+}
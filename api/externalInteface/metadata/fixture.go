@@ -0,0 +1,57 @@
+package metadata
+
+import (
+	"encoding/json"
+	"os"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+type fixtureProvider struct {
+	Books map[string]domain.BookMetadata
+}
+
+// NewFixtureProvider answers from the given books, found by either of their ISBNs.
+func NewFixtureProvider(books []domain.BookMetadata) usecases.BookMetadataProvider {
+	f := &fixtureProvider{Books: map[string]domain.BookMetadata{}}
+	for _, v := range books {
+		if v.ISBN != "" {
+			f.Books[domain.NormalizeISBN(v.ISBN)] = v
+		}
+		if v.ISBN13 != "" {
+			f.Books[domain.NormalizeISBN(v.ISBN13)] = v
+		}
+	}
+	return f
+}
+
+// LoadFixtureProvider reads the books of a fixture provider from a JSON array of BookMetadata:
+//
+//	[{"isbn": "0441013597", "isbn13": "9780441013593", "title": "Dune", "author": "Frank Herbert",
+//	  "publisher": "Ace", "published_date": "2005", "total_pages": 896}]
+func LoadFixtureProvider(path string) (usecases.BookMetadataProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	books := make([]domain.BookMetadata, 0)
+	err = json.NewDecoder(file).Decode(&books)
+	if err != nil {
+		return nil, err
+	}
+	return NewFixtureProvider(books), nil
+}
+
+func (f *fixtureProvider) LookupISBN(isbn string) (*domain.BookMetadata, error) {
+	book, ok := f.Books[isbn]
+	if !ok && domain.ValidISBN10(isbn) {
+		book, ok = f.Books[domain.ISBN10To13(isbn)]
+	}
+	if !ok {
+		return nil, usecases.ErrNotFound
+	}
+	return &book, nil
+}
@@ -0,0 +1,62 @@
+package metadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+func TestFixtureProvider(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "fixtures.json")
+	err := os.WriteFile(file, []byte(`[
+		{"isbn": "0-441-01359-7", "isbn13": "978-0-441-01359-3", "title": "Dune"},
+		{"isbn13": "9780140283334", "title": "Lord of the Flies"}
+	]`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider, err := LoadFixtureProvider(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		isbn    string
+		title   string
+		wantErr error
+	}{
+		{isbn: "0441013597", title: "Dune"},
+		{isbn: "9780441013593", title: "Dune"},
+		// an ISBN-10 finds a book known by its ISBN-13 only
+		{isbn: "0140283331", title: "Lord of the Flies"},
+		{isbn: "9780140283334", title: "Lord of the Flies"},
+		{isbn: "0306406152", wantErr: usecases.ErrNotFound},
+		{isbn: "", wantErr: usecases.ErrNotFound},
+	}
+	for _, tt := range tests {
+		book, err := provider.LookupISBN(tt.isbn)
+		if err != tt.wantErr {
+			t.Errorf("LookupISBN(%q): got error %v, want %v", tt.isbn, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr == nil && book.Title != tt.title {
+			t.Errorf("LookupISBN(%q): got title %q, want %q", tt.isbn, book.Title, tt.title)
+		}
+	}
+}
+
+func TestLoadFixtureProviderInvalid(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "fixtures.json")
+	err := os.WriteFile(file, []byte(`{"isbn": "0441013597"}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFixtureProvider(file); err == nil {
+		t.Error("loaded an object as a list of books")
+	}
+	if _, err := LoadFixtureProvider(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("loaded a missing file")
+	}
+}
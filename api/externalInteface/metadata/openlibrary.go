@@ -0,0 +1,115 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+type openLibraryProvider struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewOpenLibraryProvider looks books up through the Books API of OpenLibrary at baseURL,
+// or of any service answering in the same format.
+func NewOpenLibraryProvider(baseURL string, timeout time.Duration) usecases.BookMetadataProvider {
+	return &openLibraryProvider{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type openLibraryName struct {
+	Name string `json:"name"`
+}
+
+type openLibraryBook struct {
+	Title         string            `json:"title"`
+	Subtitle      string            `json:"subtitle"`
+	Authors       []openLibraryName `json:"authors"`
+	Publishers    []openLibraryName `json:"publishers"`
+	PublishDate   string            `json:"publish_date"`
+	NumberOfPages uint              `json:"number_of_pages"`
+	Identifiers   struct {
+		ISBN10 []string `json:"isbn_10"`
+		ISBN13 []string `json:"isbn_13"`
+	} `json:"identifiers"`
+}
+
+func (o *openLibraryProvider) LookupISBN(isbn string) (*domain.BookMetadata, error) {
+	key := "ISBN:" + isbn
+	query := url.Values{"bibkeys": {key}, "format": {"json"}, "jscmd": {"data"}}
+	resp, err := o.Client.Get(o.BaseURL + "/api/books?" + query.Encode())
+	if err != nil {
+		log.Println("OpenLibrary: ", err.Error())
+		return nil, usecases.ErrMetadataUnavailable
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Println("OpenLibrary: ", fmt.Errorf("unexpected status %s", resp.Status))
+		return nil, usecases.ErrMetadataUnavailable
+	}
+
+	// the answer maps each requested key to its book and leaves unknown keys out
+	var books map[string]openLibraryBook
+	err = json.NewDecoder(resp.Body).Decode(&books)
+	if err != nil {
+		log.Println("OpenLibrary: ", err.Error())
+		return nil, usecases.ErrMetadataUnavailable
+	}
+	book, ok := books[key]
+	if !ok {
+		return nil, usecases.ErrNotFound
+	}
+
+	metadata := domain.BookMetadata{
+		Title:         book.Title,
+		PublishedDate: book.PublishDate,
+		TotalPages:    book.NumberOfPages,
+	}
+	if book.Subtitle != "" {
+		metadata.Title += ": " + book.Subtitle
+	}
+	authors := make([]string, 0, len(book.Authors))
+	for _, v := range book.Authors {
+		authors = append(authors, v.Name)
+	}
+	metadata.Author = strings.Join(authors, ", ")
+	if len(book.Publishers) > 0 {
+		metadata.Publisher = book.Publishers[0].Name
+	}
+	metadata.ISBN, metadata.ISBN13 = editionISBNs(isbn, book.Identifiers.ISBN10, book.Identifiers.ISBN13)
+	return &metadata, nil
+}
+
+// editionISBNs picks the valid ISBNs of the edition, preferring the one that was looked up.
+func editionISBNs(isbn string, isbn10s []string, isbn13s []string) (string, string) {
+	var isbn10, isbn13 string
+	if domain.ValidISBN10(isbn) {
+		isbn10 = isbn
+	} else {
+		isbn13 = isbn
+	}
+	for _, v := range isbn10s {
+		if v = domain.NormalizeISBN(v); isbn10 == "" && domain.ValidISBN10(v) {
+			isbn10 = v
+		}
+	}
+	for _, v := range isbn13s {
+		if v = domain.NormalizeISBN(v); isbn13 == "" && domain.ValidISBN13(v) {
+			isbn13 = v
+		}
+	}
+	if isbn13 == "" && isbn10 != "" {
+		isbn13 = domain.ISBN10To13(isbn10)
+	}
+	return isbn10, isbn13
+}
@@ -0,0 +1,109 @@
+package metadata
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+const openLibraryDune = `{"ISBN:0441013597": {
+	"title": "Dune",
+	"subtitle": "Deluxe Edition",
+	"authors": [{"name": "Frank Herbert"}],
+	"publishers": [{"name": "Ace"}, {"name": "Chilton"}],
+	"publish_date": "2005",
+	"number_of_pages": 896,
+	"identifiers": {"isbn_10": ["0441013597"], "isbn_13": ["978-0-441-01359-3"]}
+}}`
+
+func newOpenLibraryServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/books" || q.Get("format") != "json" || q.Get("jscmd") != "data" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch q.Get("bibkeys") {
+		case "ISBN:0441013597":
+			w.Write([]byte(openLibraryDune))
+		case "ISBN:9999999999":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "ISBN:8888888888":
+			w.Write([]byte("<html>"))
+		default:
+			w.Write([]byte("{}"))
+		}
+	}))
+}
+
+func TestOpenLibraryLookupISBN(t *testing.T) {
+	server := newOpenLibraryServer(t)
+	defer server.Close()
+	provider := NewOpenLibraryProvider(server.URL+"/", time.Second)
+
+	book, err := provider.LookupISBN("0441013597")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := domain.BookMetadata{
+		ISBN:          "0441013597",
+		ISBN13:        "9780441013593",
+		Title:         "Dune: Deluxe Edition",
+		Author:        "Frank Herbert",
+		Publisher:     "Ace",
+		PublishedDate: "2005",
+		TotalPages:    896,
+	}
+	if *book != want {
+		t.Fatalf("got %+v, want %+v", *book, want)
+	}
+
+	tests := map[string]error{
+		"0306406152": usecases.ErrNotFound,
+		"9999999999": usecases.ErrMetadataUnavailable,
+		"8888888888": usecases.ErrMetadataUnavailable,
+	}
+	for isbn, wantErr := range tests {
+		if _, err := provider.LookupISBN(isbn); err != wantErr {
+			t.Errorf("LookupISBN(%q): got error %v, want %v", isbn, err, wantErr)
+		}
+	}
+}
+
+func TestOpenLibraryUnreachable(t *testing.T) {
+	server := newOpenLibraryServer(t)
+	server.Close()
+	_, err := NewOpenLibraryProvider(server.URL, time.Second).LookupISBN("0441013597")
+	if err != usecases.ErrMetadataUnavailable {
+		t.Fatalf("got error %v, want %v", err, usecases.ErrMetadataUnavailable)
+	}
+}
+
+func TestEditionISBNs(t *testing.T) {
+	tests := []struct {
+		name    string
+		isbn    string
+		isbn10s []string
+		isbn13s []string
+		want10  string
+		want13  string
+	}{
+		{"looked up by ISBN-10", "0441013597", nil, []string{"9780441013593"}, "0441013597", "9780441013593"},
+		{"looked up by ISBN-13", "9780441013593", []string{"0-441-01359-7"}, nil, "0441013597", "9780441013593"},
+		{"looked up ISBN wins", "9780441013593", nil, []string{"9780306406157"}, "", "9780441013593"},
+		{"invalid ISBNs skipped", "9780441013593", []string{"0441013598", "0441013597"}, nil, "0441013597", "9780441013593"},
+		{"ISBN-13 derived", "0441013597", nil, []string{"9780441013594"}, "0441013597", "9780441013593"},
+		{"no ISBN-10", "9791090636071", nil, nil, "", "9791090636071"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isbn10, isbn13 := editionISBNs(tt.isbn, tt.isbn10s, tt.isbn13s)
+			if isbn10 != tt.want10 || isbn13 != tt.want13 {
+				t.Fatalf("got %q and %q, want %q and %q", isbn10, isbn13, tt.want10, tt.want13)
+			}
+		})
+	}
+}
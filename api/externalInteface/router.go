@@ -2,6 +2,8 @@ package externalInteface
 
 import (
 	"bookshelf-web-api_gin_clean/api/externalInteface/database"
+	"bookshelf-web-api_gin_clean/api/externalInteface/metadata"
+	"bookshelf-web-api_gin_clean/api/externalInteface/storage"
 	"bookshelf-web-api_gin_clean/api/gateway/controllers"
 
 	"github.com/gin-gonic/gin"
@@ -12,21 +14,103 @@ func Router() *gin.Engine {
 	router.Use(Options, authMiddleware())
 	// router.Use(Options, authMiddlewareTest())
 	conn := database.NewSqlConnection()
+	fileStorage := storage.NewStorage()
 
-	b := controllers.NewBookController(&conn)
+	b := controllers.NewBookController(&conn, fileStorage)
 	d := controllers.NewDescriptionController(&conn)
+	a := controllers.NewAuthorController(&conn)
+	s := controllers.NewSearchController(&conn)
+	t := controllers.NewTagController(&conn)
+	r := controllers.NewRatingController(&conn)
+	se := controllers.NewSeriesController(&conn)
+	h := controllers.NewHighlightController(&conn)
+	st := controllers.NewStatsController(&conn)
+	g := controllers.NewGoalController(&conn)
+	im := controllers.NewImportController(&conn)
+	ex := controllers.NewExportController(&conn)
+	md := controllers.NewMetadataController(&conn, metadata.NewProvider())
+	co := controllers.NewCoverController(&conn, fileStorage)
 
 	router.GET("/books", b.GetAllBooks)
 	router.POST("/books", b.CreateBook)
+	router.GET("/books/next", se.GetNextBooks)
 
 	router.GET("/book/:id", b.GetBook)
+	router.PUT("/book/:id", b.UpdateBook)
+	router.PATCH("/book/:id", b.UpdateBook)
+	router.DELETE("/book/:id", b.DeleteBook)
 
-	router.PUT("/book/:id/state/start", b.ChangeBookStatus)
-	router.PUT("/book/:id/state/end", b.ChangeBookStatus)
+	router.PUT("/book/:id/state/:transition", b.ChangeBookStatus)
+	router.GET("/book/:id/sessions", b.GetBookSessions)
+	router.PUT("/book/:id/tags", b.SetBookTags)
+	router.PUT("/book/:id/rating", b.ChangeBookRating)
+	router.GET("/book/:id/progress", b.GetBookProgress)
+	router.POST("/book/:id/progress", b.LogBookProgress)
+	router.GET("/book/:id/export.md", ex.ExportBookMarkdown)
+	router.POST("/book/:id/metadata", md.FillBookMetadata)
+	router.GET("/book/:id/cover", co.GetCover)
+	router.POST("/book/:id/cover", co.UploadCover)
+	router.DELETE("/book/:id/cover", co.DeleteCover)
 
 	router.GET("/book/:id/description", d.GetAllDescriptions)
 	router.POST("/book/:id/description", d.CreateDescription)
+	router.GET("/description/:id", d.GetDescription)
+	router.PUT("/description/:id", d.UpdateDescription)
+	router.PATCH("/description/:id", d.UpdateDescription)
 	router.DELETE("/description/:id", d.DeleteDescription)
 
+	router.GET("/book/:id/highlights", h.GetAllHighlights)
+	router.POST("/book/:id/highlights", h.CreateHighlight)
+	router.GET("/book/:id/highlights/:highlight_id", h.GetHighlight)
+	router.PUT("/book/:id/highlights/:highlight_id", h.UpdateHighlight)
+	router.PATCH("/book/:id/highlights/:highlight_id", h.PatchHighlight)
+	router.DELETE("/book/:id/highlights/:highlight_id", h.DeleteHighlight)
+
+	router.GET("/authors", a.GetAllAuthors)
+	router.POST("/authors", a.CreateAuthor)
+	router.GET("/author/:id", a.GetAuthor)
+	router.PUT("/author/:id", a.UpdateAuthor)
+	router.PATCH("/author/:id", a.UpdateAuthor)
+	router.DELETE("/author/:id", a.DeleteAuthor)
+
+	router.GET("/tags", t.GetAllTags)
+	router.POST("/tags", t.CreateTag)
+	router.GET("/tag/:id", t.GetTag)
+	router.PUT("/tag/:id", t.UpdateTag)
+	router.PATCH("/tag/:id", t.UpdateTag)
+	router.DELETE("/tag/:id", t.DeleteTag)
+
+	router.GET("/series", se.GetAllSeries)
+	router.POST("/series", se.CreateSeries)
+	router.GET("/series/:id", se.GetSeries)
+	router.PUT("/series/:id", se.UpdateSeries)
+	router.PATCH("/series/:id", se.UpdateSeries)
+	router.DELETE("/series/:id", se.DeleteSeries)
+	router.POST("/series/:id/books", se.InsertSeriesBook)
+	router.PUT("/series/:id/books", se.ReorderSeriesBooks)
+	router.DELETE("/series/:id/books/:book_id", se.RemoveSeriesBook)
+
+	router.GET("/ratings/authors", r.GetAuthorRatings)
+	router.GET("/ratings/tags", r.GetTagRatings)
+
+	router.GET("/search", s.Search)
+
+	router.GET("/stats", st.GetStats)
+
+	router.GET("/goals", g.GetAllGoals)
+	router.GET("/goals/:year", g.GetGoal)
+	router.PUT("/goals/:year", g.SetGoal)
+	router.DELETE("/goals/:year", g.DeleteGoal)
+
+	router.POST("/import/csv", im.ImportCSV)
+	router.POST("/import/goodreads", im.ImportGoodreads)
+	router.POST("/import/json", im.ImportJSON)
+	router.POST("/import/kindle", im.ImportKindle)
+	router.GET("/import/jobs/:id", im.GetImportJob)
+
+	router.GET("/export", ex.Export)
+
+	router.GET("/metadata/isbn/:isbn", md.LookupISBN)
+
 	return router
 }
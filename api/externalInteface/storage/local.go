@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/kelseyhightower/envconfig"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+var errInvalidKey = errors.New("storage key must be a relative path inside the storage")
+
+type Config struct {
+	Dir string `envconfig:"storage_dir" default:"./storage"`
+}
+
+func LoadConfig() (*Config, error) {
+	var config = Config{}
+	if err := envconfig.Process("APP", &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// NewStorage returns a local storage in the directory APP_STORAGE_DIR names.
+func NewStorage() usecases.FileStorage {
+	config, err := LoadConfig()
+	if err != nil {
+		panic(err.Error())
+	}
+	return NewLocalStorage(config.Dir)
+}
+
+type localStorage struct {
+	Root string
+}
+
+// NewLocalStorage keeps files below root on the local file system.
+func NewLocalStorage(root string) usecases.FileStorage {
+	return &localStorage{Root: root}
+}
+
+func (l *localStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+key || strings.Contains(key, "\\") {
+		return "", errInvalidKey
+	}
+	return filepath.Join(l.Root, filepath.FromSlash(clean)), nil
+}
+
+// Put writes the file next to its final place first, so readers never see half a file.
+func (l *localStorage) Put(key string, data []byte) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(name), 0755)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (l *localStorage) Open(key string) (io.ReadSeekCloser, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, usecases.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (l *localStorage) Delete(key string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(name)
+	if os.IsNotExist(err) {
+		return usecases.ErrNotFound
+	}
+	return err
+}
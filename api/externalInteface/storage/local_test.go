@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+func TestLocalStoragePath(t *testing.T) {
+	root := filepath.Join("srv", "storage")
+	l := &localStorage{Root: root}
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"covers/1/abc-full.jpg", filepath.Join(root, "covers", "1", "abc-full.jpg")},
+		{"x", filepath.Join(root, "x")},
+		{"", ""},
+		{"/", ""},
+		{"../x", ""},
+		{"a/../../b", ""},
+		{"a/../b", ""},
+		{"./a", ""},
+		{"/a", ""},
+		{"a//b", ""},
+		{"a/", ""},
+		{`a\b`, ""},
+		{`..\x`, ""},
+	}
+	for _, tt := range tests {
+		got, err := l.path(tt.key)
+		if tt.want == "" {
+			if err != errInvalidKey {
+				t.Errorf("path(%q) = %q, %v; want errInvalidKey", tt.key, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("path(%q) = %q, %v; want %q", tt.key, got, err, tt.want)
+		}
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	l := NewLocalStorage(t.TempDir())
+	err := l.Put("covers/1/a.jpg", []byte("jpeg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := l.Open("covers/1/a.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	if err := l.Delete("covers/1/a.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Open("covers/1/a.jpg"); err != usecases.ErrNotFound {
+		t.Errorf("Open after Delete: got %v, want ErrNotFound", err)
+	}
+	if err := l.Delete("covers/1/a.jpg"); err != usecases.ErrNotFound {
+		t.Errorf("Delete twice: got %v, want ErrNotFound", err)
+	}
+	if err := l.Put("../a.jpg", []byte("jpeg")); err != errInvalidKey {
+		t.Errorf("Put outside the root: got %v, want errInvalidKey", err)
+	}
+}
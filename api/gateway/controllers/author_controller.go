@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type authorController struct {
+	UseCase usecases.AuthorUseCase
+}
+
+type AuthorController interface {
+	GetAllAuthors(c *gin.Context)
+	GetAuthor(c *gin.Context)
+	CreateAuthor(c *gin.Context)
+	UpdateAuthor(c *gin.Context)
+	DeleteAuthor(c *gin.Context)
+}
+
+func NewAuthorController(dbConnection repositories.DBConnection) AuthorController {
+	repo := repositories.NewAuthorRepository(dbConnection)
+	u := usecases.NewAuthorUseCase(repo)
+	return &authorController{UseCase: u}
+}
+
+type AuthorForm struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func (a *authorController) GetAllAuthors(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetAllAuthors: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ByAccountId(filter, accountId)
+
+	authors, err := a.UseCase.GetAllAuthors(filter)
+	if err != nil {
+		log.Println("GetAllAuthors: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: authors})
+}
+
+func (a *authorController) GetAuthor(c *gin.Context) {
+	authorId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("GetAuthor: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetAuthor: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, authorId)
+	usecases.ByAccountId(filter, accountId)
+
+	author, err := a.UseCase.GetAuthor(filter)
+	if err != nil {
+		log.Println("GetAuthor: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: author})
+}
+
+func (a *authorController) CreateAuthor(c *gin.Context) {
+	form := AuthorForm{}
+	err := c.ShouldBind(&form)
+	if err != nil {
+		log.Println("CreateAuthor: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("CreateAuthor: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	author := domain.Author{
+		AccountID: accountId,
+		Name:      strings.TrimSpace(form.Name),
+	}
+
+	newAuthor, err := a.UseCase.CreateAuthor(author)
+	if err != nil {
+		log.Println("CreateAuthor: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: newAuthor})
+}
+
+func (a *authorController) UpdateAuthor(c *gin.Context) {
+	authorId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("UpdateAuthor: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("UpdateAuthor: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	form := AuthorForm{}
+	err = c.ShouldBind(&form)
+	if err != nil {
+		log.Println("UpdateAuthor: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, authorId)
+	usecases.ByAccountId(filter, accountId)
+
+	author, err := a.UseCase.GetAuthor(filter)
+	if err != nil {
+		log.Println("UpdateAuthor: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	author.Name = strings.TrimSpace(form.Name)
+
+	updatedAuthor, err := a.UseCase.UpdateAuthor(*author, filter)
+	if err != nil {
+		log.Println("UpdateAuthor: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: updatedAuthor})
+}
+
+func (a *authorController) DeleteAuthor(c *gin.Context) {
+	authorId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("DeleteAuthor: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("DeleteAuthor: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, authorId)
+	usecases.ByAccountId(filter, accountId)
+
+	err = a.UseCase.DeleteAuthor(filter)
+	if err != nil {
+		log.Println("DeleteAuthor: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
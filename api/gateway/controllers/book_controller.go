@@ -11,6 +11,9 @@ import (
 	"log"
 	"errors"
 	"bookshelf-web-api_gin_clean/api/domain"
+	"strings"
+	"time"
+	"io"
 )
 
 type bookController struct {
@@ -21,19 +24,98 @@ type BookController interface {
 	GetAllBooks(c *gin.Context)
 	GetBook(c *gin.Context)
 	CreateBook(c *gin.Context)
+	UpdateBook(c *gin.Context)
+	DeleteBook(c *gin.Context)
 	ChangeBookStatus(c *gin.Context)
+	GetBookSessions(c *gin.Context)
+	SetBookTags(c *gin.Context)
+	ChangeBookRating(c *gin.Context)
+	LogBookProgress(c *gin.Context)
+	GetBookProgress(c *gin.Context)
 }
 
-func NewBookController(dbConnection repositories.DBConnection) BookController {
-	repo := repositories.NewBookRepository(dbConnection)
-	u := usecases.NewBookUseCase(repo)
+func NewBookController(dbConnection repositories.DBConnection, storage usecases.FileStorage) BookController {
+	deps := newBookUseCaseDeps(dbConnection)
+	deps.Storage = storage
+	u := usecases.NewBookUseCase(deps)
 	return &bookController{UseCase: u}
 }
 
+// newBookUseCaseDeps builds every repository of a BookUseCase on the connection.
+func newBookUseCaseDeps(dbConnection repositories.DBConnection) usecases.BookUseCaseDeps {
+	return usecases.BookUseCaseDeps{
+		BookRepo:     repositories.NewBookRepository(dbConnection),
+		AuthorRepo:   repositories.NewAuthorRepository(dbConnection),
+		SessionRepo:  repositories.NewReadingSessionRepository(dbConnection),
+		TagRepo:      repositories.NewTagRepository(dbConnection),
+		SeriesRepo:   repositories.NewSeriesRepository(dbConnection),
+		ProgressRepo: repositories.NewProgressRepository(dbConnection),
+		Transactor:   repositories.NewTransactor(dbConnection),
+	}
+}
+
 type BookForm struct {
-	Title      string  `json:"title" binding:"required"`
-	AuthorID   uint64  `json:"author_id"`
-	AuthorName *string `json:"author_name"`
+	Title         string  `json:"title" binding:"required"`
+	AuthorID      uint64  `json:"author_id"`
+	AuthorName    *string `json:"author_name"`
+	TotalPages    uint    `json:"total_pages"`
+	ISBN          string  `json:"isbn"`
+	ISBN13        string  `json:"isbn13"`
+	Publisher     string  `json:"publisher"`
+	PublishedDate string  `json:"published_date"`
+}
+
+type BookUpdateForm struct {
+	Title         *string      `json:"title"`
+	AuthorID      *uint64      `json:"author_id"`
+	AuthorName    *string      `json:"author_name"`
+	StartAt       nullableTime `json:"start_at"`
+	EndAt         nullableTime `json:"end_at"`
+	ReadState     *string      `json:"read_state"`
+	TotalPages    *uint        `json:"total_pages"`
+	ISBN          *string      `json:"isbn"`
+	ISBN13        *string      `json:"isbn13"`
+	Publisher     *string      `json:"publisher"`
+	PublishedDate *string      `json:"published_date"`
+}
+
+// nullableTime tells an explicit null apart from a missing field in partial updates.
+type nullableTime struct {
+	Set   bool
+	Value domain.NullTime
+}
+
+func (n *nullableTime) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	return n.Value.UnmarshalJSON(data)
+}
+
+// nullableFloat64 tells an explicit null apart from a missing field in partial updates.
+type nullableFloat64 struct {
+	Set   bool
+	Value domain.NullFloat64
+}
+
+func (n *nullableFloat64) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	return n.Value.UnmarshalJSON(data)
+}
+
+type BookTagsForm struct {
+	TagIDs []uint64 `json:"tag_ids"`
+}
+
+// ProgressForm logs progress by either page or percent; at defaults to now.
+type ProgressForm struct {
+	Page    *uint      `json:"page"`
+	Percent *float64   `json:"percent"`
+	At      *time.Time `json:"at"`
+}
+
+// RatingForm leaves the rating as it is when rating is missing and clears it when rating is null.
+type RatingForm struct {
+	Rating nullableFloat64 `json:"rating"`
+	Review *string         `json:"review"`
 }
 
 type Response struct {
@@ -44,6 +126,7 @@ func parseStatus(s string) (*domain.ReadState, error) {
 	n := domain.NotReadValue
 	r := domain.ReadingValue
 	r2 := domain.ReadValue
+	a := domain.AbandonedValue
 	switch s {
 	case "not_read":
 		return &n, nil
@@ -51,13 +134,30 @@ func parseStatus(s string) (*domain.ReadState, error) {
 		return &r, nil
 	case "read":
 		return &r2, nil
+	case "abandoned":
+		return &a, nil
 	default:
 		return nil, errors.New("invalid read status")
 	}
 }
 
+// formAuthor builds the author reference sent with a book form.
+// An id takes precedence over a name.
+func formAuthor(authorId *uint64, authorName *string) *domain.Author {
+	author := domain.Author{}
+	if authorId != nil && *authorId != 0 {
+		author.ID = *authorId
+		return &author
+	}
+	if authorName == nil || strings.TrimSpace(*authorName) == "" {
+		return nil
+	}
+	author.Name = strings.TrimSpace(*authorName)
+	return &author
+}
+
 func (b *bookController) GetAllBooks(c *gin.Context) {
-	filter := map[string]interface{}{}
+	filter := usecases.NewFilter()
 
 	accountId, ok := c.MustGet("account_id").(string)
 	if !ok {
@@ -67,14 +167,24 @@ func (b *bookController) GetAllBooks(c *gin.Context) {
 	}
 	usecases.ByAccountId(filter, accountId)
 
-	page, perPage, err := GetPaginate(c)
+	paging, err := GetPaging(c)
 	if err != nil {
-		log.Println("GetPaginate: ", err.Error())
+		log.Println("GetPaging: ", err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusBadRequest})
 		return
 	}
 
-	sortKey := c.Query("sort_key")
+	sortParam := c.Query("sort")
+	if sortParam == "" && c.Query("sort_key") != "" {
+		// sort_key is the former single key parameter, which always sorted descending
+		sortParam = "-" + c.Query("sort_key")
+	}
+	sorts, err := usecases.ParseSort(sortParam, usecases.BookSortFields)
+	if err != nil {
+		log.Println("GetAllBooks: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
 
 	readStatusStr := c.Query("status")
 	if readStatusStr != "" {
@@ -87,10 +197,44 @@ func (b *bookController) GetAllBooks(c *gin.Context) {
 		usecases.ByStatus(filter, *readStatus)
 	}
 
-	books, err := b.UseCase.GetAllBooks(filter, page, perPage, sortKey)
+	for param, operator := range map[string]usecases.Operator{"min_rating": usecases.GteOperator, "max_rating": usecases.LteOperator} {
+		ratingStr := c.Query(param)
+		if ratingStr == "" {
+			continue
+		}
+		rating, err := strconv.ParseFloat(ratingStr, 64)
+		if err != nil {
+			log.Println("GetAllBooks: ", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+			return
+		}
+		filter.Where(usecases.RatingField, operator, rating)
+	}
+
+	tagNames := splitList(c.Query("tags"))
+	if len(tagNames) > 0 {
+		matchAll := false
+		switch c.DefaultQuery("tags_match", "any") {
+		case "any":
+		case "all":
+			matchAll = true
+		default:
+			log.Println("GetAllBooks: ", errors.New("invalid tags_match"))
+			c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+			return
+		}
+		err = b.UseCase.FilterByTags(filter, accountId, tagNames, matchAll)
+		if err != nil {
+			log.Println("GetAllBooks: ", err.Error())
+			errorResponse(c, err)
+			return
+		}
+	}
+
+	books, err := b.UseCase.GetAllBooks(filter, paging, sorts)
 	if err != nil {
 		log.Println("GetAllBooks: ", err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusInternalServerError})
+		errorResponse(c, err)
 		return
 	}
 
@@ -118,7 +262,7 @@ func (b *bookController) GetBook(c *gin.Context) {
 	book, err := b.UseCase.GetBook(filter)
 	if err != nil {
 		log.Println(err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		errorResponse(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, Response{Content: book})
@@ -143,38 +287,345 @@ func (b *bookController) CreateBook(c *gin.Context) {
 	book.Title = form.Title
 	book.AccountID = accountId
 	book.ReadState = domain.NotReadValue
+	book.Author = formAuthor(&form.AuthorID, form.AuthorName)
+	book.TotalPages = form.TotalPages
+	book.ISBN = domain.NormalizeISBN(form.ISBN)
+	book.ISBN13 = domain.NormalizeISBN(form.ISBN13)
+	book.Publisher = strings.TrimSpace(form.Publisher)
+	book.PublishedDate = strings.TrimSpace(form.PublishedDate)
 
 	newBook, err := b.UseCase.CreateBook(book)
 	if err != nil {
 		log.Println(err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		errorResponse(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, Response{Content: newBook})
 }
 
-func (b *bookController) ChangeBookStatus(c *gin.Context) {
+func (b *bookController) UpdateBook(c *gin.Context) {
 	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
-		log.Println("StartReadBook: ", err.Error())
+		log.Println("UpdateBook: ", err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
 		return
 	}
 	accountId, ok := c.MustGet("account_id").(string)
 	if !ok {
-		log.Println("StartReadBook: ", errors.New("accountId parser error"))
+		log.Println("UpdateBook: ", errors.New("accountId parser error"))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
 		return
 	}
+
+	form := BookUpdateForm{}
+	err = c.ShouldBindJSON(&form)
+	if err != nil {
+		log.Println("UpdateBook: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
 	filter := usecases.NewFilter()
 	usecases.ById(filter, bookId)
 	usecases.ByAccountId(filter, accountId)
 
-	err = b.UseCase.ChangeStatus(filter)
+	book, err := b.UseCase.GetBook(filter)
+	if err != nil {
+		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
+
+	if form.Title != nil {
+		book.Title = *form.Title
+	}
+	if form.AuthorID != nil || form.AuthorName != nil {
+		book.Author = formAuthor(form.AuthorID, form.AuthorName)
+	}
+	if form.TotalPages != nil {
+		book.TotalPages = *form.TotalPages
+	}
+	if form.ISBN != nil {
+		book.ISBN = domain.NormalizeISBN(*form.ISBN)
+	}
+	if form.ISBN13 != nil {
+		book.ISBN13 = domain.NormalizeISBN(*form.ISBN13)
+	}
+	if form.Publisher != nil {
+		book.Publisher = strings.TrimSpace(*form.Publisher)
+	}
+	if form.PublishedDate != nil {
+		book.PublishedDate = strings.TrimSpace(*form.PublishedDate)
+	}
+	if form.StartAt.Set {
+		book.StartAt = form.StartAt.Value
+	}
+	if form.EndAt.Set {
+		book.EndAt = form.EndAt.Value
+	}
+	if form.ReadState != nil {
+		readState, err := parseStatus(*form.ReadState)
+		if err != nil {
+			log.Println("UpdateBook: ", err.Error())
+			c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+			return
+		}
+		book.ReadState = *readState
+	}
+
+	updatedBook, err := b.UseCase.UpdateBook(*book, filter)
 	if err != nil {
 		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: updatedBook})
+}
+
+func (b *bookController) DeleteBook(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("DeleteBook: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("DeleteBook: ", errors.New("accountId parser error"))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
 		return
 	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+
+	err = b.UseCase.DeleteBook(filter)
+	if err != nil {
+		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
 	c.Status(http.StatusOK)
 }
+
+type StatusForm struct {
+	At *time.Time `json:"at"`
+}
+
+func parseTransition(s string) (domain.ReadTransition, error) {
+	switch s {
+	case "start":
+		return domain.StartTransition, nil
+	case "finish", "end":
+		return domain.FinishTransition, nil
+	case "abandon":
+		return domain.AbandonTransition, nil
+	case "reset":
+		return domain.ResetTransition, nil
+	default:
+		return "", errors.New("invalid read state transition")
+	}
+}
+
+func (b *bookController) ChangeBookStatus(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("ChangeBookStatus: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("ChangeBookStatus: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	transition, err := parseTransition(c.Param("transition"))
+	if err != nil {
+		log.Println("ChangeBookStatus: ", err.Error())
+		c.JSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	// the body is optional; it only carries a backdated timestamp
+	form := StatusForm{}
+	err = c.ShouldBindJSON(&form)
+	if err != nil && err != io.EOF {
+		log.Println("ChangeBookStatus: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+
+	book, err := b.UseCase.ChangeStatus(filter, transition, form.At)
+	if err != nil {
+		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: book})
+}
+
+func (b *bookController) GetBookSessions(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("GetBookSessions: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetBookSessions: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+
+	sessions, err := b.UseCase.GetSessions(filter)
+	if err != nil {
+		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: sessions})
+}
+
+func (b *bookController) SetBookTags(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("SetBookTags: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("SetBookTags: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	form := BookTagsForm{}
+	err = c.ShouldBindJSON(&form)
+	if err != nil {
+		log.Println("SetBookTags: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+
+	book, err := b.UseCase.SetTags(filter, form.TagIDs)
+	if err != nil {
+		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: book})
+}
+
+func (b *bookController) ChangeBookRating(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("ChangeBookRating: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("ChangeBookRating: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	form := RatingForm{}
+	err = c.ShouldBindJSON(&form)
+	if err != nil {
+		log.Println("ChangeBookRating: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+
+	var rating *domain.NullFloat64
+	if form.Rating.Set {
+		rating = &form.Rating.Value
+	}
+	book, err := b.UseCase.ChangeRating(filter, rating, form.Review)
+	if err != nil {
+		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: book})
+}
+
+func (b *bookController) LogBookProgress(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("LogBookProgress: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("LogBookProgress: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	form := ProgressForm{}
+	err = c.ShouldBindJSON(&form)
+	if err != nil {
+		log.Println("LogBookProgress: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+
+	book, err := b.UseCase.LogProgress(filter, form.Page, form.Percent, form.At)
+	if err != nil {
+		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: book})
+}
+
+func (b *bookController) GetBookProgress(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("GetBookProgress: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetBookProgress: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+
+	entries, err := b.UseCase.GetProgress(filter)
+	if err != nil {
+		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: entries})
+}
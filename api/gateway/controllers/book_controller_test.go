@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"bookshelf-web-api_gin_clean/api/usecases/usecasetest"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestChangeBookRating(t *testing.T) {
+	tests := []struct {
+		body   string
+		rating domain.NullFloat64
+		review string
+		status int
+	}{
+		{`{"review": "still good"}`, domain.NewNullFloat(4), "still good", http.StatusOK},
+		{`{"rating": 3.5}`, domain.NewNullFloat(3.5), "great", http.StatusOK},
+		{`{"rating": null}`, domain.NullFloat64{}, "great", http.StatusOK},
+		{`{"rating": null, "review": ""}`, domain.NullFloat64{}, "", http.StatusOK},
+		{`{"rating": 3.3}`, domain.NewNullFloat(4), "great", http.StatusBadRequest},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			book := domain.Book{AccountID: "account-a", Title: "Dune", ReadState: domain.NotReadValue,
+				Rating: domain.NewNullFloat(4), Review: "great"}
+			book.ID = 1
+			bookRepo := &usecasetest.BookRepository{Books: domain.Books{book}}
+			b := bookController{UseCase: usecases.NewBookUseCase(usecases.BookUseCaseDeps{BookRepo: bookRepo})}
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("account_id", "account-a")
+			})
+			router.PUT("/book/:id/rating", b.ChangeBookRating)
+			req := httptest.NewRequest(http.MethodPut, "/book/1/rating", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+			got := bookRepo.Books[0]
+			if got.Rating != tt.rating || got.Review != tt.review {
+				t.Fatalf("got rating %+v and review %q, want %+v and %q", got.Rating, got.Review, tt.rating, tt.review)
+			}
+		})
+	}
+}
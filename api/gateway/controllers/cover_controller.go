@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxCoverSize is the largest cover image accepted for upload.
+const maxCoverSize = 10 << 20
+
+type coverController struct {
+	UseCase usecases.CoverUseCase
+}
+
+type CoverController interface {
+	UploadCover(c *gin.Context)
+	GetCover(c *gin.Context)
+	DeleteCover(c *gin.Context)
+}
+
+func NewCoverController(dbConnection repositories.DBConnection, storage usecases.FileStorage) CoverController {
+	repo := repositories.NewBookRepository(dbConnection)
+	u := usecases.NewCoverUseCase(repo, storage)
+	return &coverController{UseCase: u}
+}
+
+// UploadCover replaces the cover of the book with the image in the "file" field of a multipart form.
+func (co *coverController) UploadCover(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("UploadCover: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("UploadCover: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxCoverSize)
+	header, err := c.FormFile("file")
+	if err != nil {
+		log.Println("UploadCover: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		log.Println("UploadCover: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.Println("UploadCover: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	book, err := co.UseCase.UploadCover(filter, data)
+	if err != nil {
+		log.Println("UploadCover: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: book})
+}
+
+// GetCover serves the cover in the size given by size=thumbnail or full, full by default.
+// A request carrying the current version as v, as cover_url does, may be cached for good;
+// any other is revalidated against the ETag.
+func (co *coverController) GetCover(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("GetCover: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetCover: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+	size := domain.CoverSize(c.DefaultQuery("size", string(domain.FullCover)))
+	file, version, err := co.UseCase.GetCover(filter, size)
+	if err != nil {
+		log.Println("GetCover: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	defer file.Close()
+
+	if c.Query("v") == version {
+		c.Header("Cache-Control", "private, max-age=31536000, immutable")
+	} else {
+		c.Header("Cache-Control", "private, no-cache")
+	}
+	c.Header("ETag", `"`+version+"-"+string(size)+`"`)
+	c.Header("Content-Type", "image/jpeg")
+	http.ServeContent(c.Writer, c.Request, "", time.Time{}, file)
+}
+
+func (co *coverController) DeleteCover(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("DeleteCover: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("DeleteCover: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+	book, err := co.UseCase.DeleteCover(filter)
+	if err != nil {
+		log.Println("DeleteCover: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: book})
+}
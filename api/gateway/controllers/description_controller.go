@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"bookshelf-web-api_gin_clean/api/domain"
+	"errors"
 )
 
 type descriptionController struct {
@@ -16,7 +17,9 @@ type descriptionController struct {
 
 type DescriptionController interface {
 	GetAllDescriptions(c *gin.Context)
+	GetDescription(c *gin.Context)
 	CreateDescription(c *gin.Context)
+	UpdateDescription(c *gin.Context)
 	DeleteDescription(c *gin.Context)
 }
 
@@ -32,6 +35,10 @@ type DescriptionForm struct {
 	Content string `json:"content"`
 }
 
+type DescriptionUpdateForm struct {
+	Content string `json:"content" binding:"required"`
+}
+
 func (d descriptionController) GetAllDescriptions(c *gin.Context) {
 	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
@@ -40,22 +47,52 @@ func (d descriptionController) GetAllDescriptions(c *gin.Context) {
 		return
 	}
 
-	page, perPage, err := GetPaginate(c)
+	paging, err := GetPaging(c)
 	if err != nil {
-		log.Println("GetPaginate: ", err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusInternalServerError})
+		log.Println("GetPaging: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
 		return
 	}
 
-	filter := usecases.NewFilter()
-	usecases.ByBookId(filter, bookId)
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetAllDescriptions: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
 
-	description, err := d.UseCase.GetAllDescriptions(filter, page, perPage)
+	description, err := d.UseCase.GetAllDescriptions(bookId, accountId, paging)
 	if err != nil {
 		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: description})
+}
+
+func (d descriptionController) GetDescription(c *gin.Context) {
+	descriptionId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("GetDescription: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetDescription: ", errors.New("accountId parser error"))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
 		return
 	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, descriptionId)
+
+	description, err := d.UseCase.GetDescription(filter, accountId)
+	if err != nil {
+		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, Response{Content: description})
 }
 
@@ -67,6 +104,13 @@ func (d descriptionController) CreateDescription(c *gin.Context) {
 		return
 	}
 
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("CreateDescription: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
 	form := DescriptionForm{}
 	err = c.ShouldBind(&form)
 	if err != nil {
@@ -80,15 +124,48 @@ func (d descriptionController) CreateDescription(c *gin.Context) {
 		Content: form.Content,
 	}
 
-	newDescription, err := d.UseCase.CreateDescription(description)
+	newDescription, err := d.UseCase.CreateDescription(description, accountId)
 	if err != nil {
 		log.Println(err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		errorResponse(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, Response{Content: newDescription})
 }
 
+func (d descriptionController) UpdateDescription(c *gin.Context) {
+	descriptionId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("UpdateDescription: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("UpdateDescription: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	form := DescriptionUpdateForm{}
+	err = c.ShouldBind(&form)
+	if err != nil {
+		log.Println("UpdateDescription: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, descriptionId)
+
+	description, err := d.UseCase.UpdateDescription(form.Content, filter, accountId)
+	if err != nil {
+		log.Println(err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: description})
+}
+
 func (d descriptionController) DeleteDescription(c *gin.Context) {
 	descriptionId, err := strconv.ParseUint(c.Param("id"), 10, 64)
 	if err != nil {
@@ -96,13 +173,19 @@ func (d descriptionController) DeleteDescription(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
 		return
 	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("DeleteDescription: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
 	description := domain.Description{}
 	description.ID = descriptionId
 
-	err = d.UseCase.DeleteDescription(description)
+	err = d.UseCase.DeleteDescription(description, accountId)
 	if err != nil {
 		log.Println(err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		errorResponse(c, err)
 		return
 	}
 	c.Status(http.StatusOK)
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"bookshelf-web-api_gin_clean/api/usecases/usecasetest"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newDescriptionRouter serves the description routes as accountId over book 1 of account-a,
+// which carries description 10.
+func newDescriptionRouter(accountId string) *gin.Engine {
+	book := domain.Book{AccountID: "account-a", Title: "Dune", ReadState: domain.NotReadValue}
+	book.ID = 1
+	description := domain.Description{BookId: 1, Content: "first note"}
+	description.ID = 10
+	bookRepo := &usecasetest.BookRepository{Books: domain.Books{book}}
+	descRepo := &usecasetest.DescriptionRepository{Descriptions: domain.Descriptions{description}}
+	d := descriptionController{UseCase: usecases.NewDescriptionUseCase(descRepo, bookRepo)}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("account_id", accountId)
+	})
+	router.GET("/book/:id/description", d.GetAllDescriptions)
+	router.POST("/book/:id/description", d.CreateDescription)
+	router.GET("/description/:id", d.GetDescription)
+	router.PUT("/description/:id", d.UpdateDescription)
+	router.DELETE("/description/:id", d.DeleteDescription)
+	return router
+}
+
+func TestDescriptionRoutesOwnership(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/book/1/description", ""},
+		{http.MethodPost, "/book/1/description", `{"content": "second note"}`},
+		{http.MethodGet, "/description/10", ""},
+		{http.MethodPut, "/description/10", `{"content": "edited"}`},
+		{http.MethodDelete, "/description/10", ""},
+	}
+
+	for _, tt := range tests {
+		for _, account := range []struct {
+			id     string
+			status int
+		}{
+			{"account-a", http.StatusOK},
+			{"account-b", http.StatusNotFound},
+		} {
+			t.Run(tt.method+" "+tt.path+" as "+account.id, func(t *testing.T) {
+				req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+				req.Header.Set("Content-Type", "application/json")
+				w := httptest.NewRecorder()
+				newDescriptionRouter(account.id).ServeHTTP(w, req)
+				if w.Code != account.status {
+					t.Fatalf("got status %d, want %d: %s", w.Code, account.status, w.Body.String())
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"net/http"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+func errorStatus(err error) int {
+	if _, ok := err.(*domain.ValidationError); ok {
+		return http.StatusBadRequest
+	}
+	switch err {
+	case usecases.ErrInvalidSort,
+		usecases.ErrInvalidCursor,
+		usecases.ErrEmptyQuery,
+		usecases.ErrInvalidOrder:
+		return http.StatusBadRequest
+	case usecases.ErrNotFound:
+		return http.StatusNotFound
+	case usecases.ErrAlreadyExists, domain.ErrInvalidTransition:
+		return http.StatusConflict
+	case usecases.ErrMetadataUnavailable:
+		return http.StatusBadGateway
+	case usecases.ErrImportQueueFull:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func errorResponse(c *gin.Context, err error) {
+	status := errorStatus(err)
+	c.JSON(status, gin.H{"error": http.StatusText(status)})
+}
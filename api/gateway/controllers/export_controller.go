@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportColumns are the columns of a CSV export, which POST /import/csv reads back.
+var exportColumns = []string{
+	"title", "author", "status", "read_count", "start_at", "end_at", "rating",
+	"review", "isbn", "isbn13", "total_pages", "publisher", "published_date", "cover_url",
+	"tags", "descriptions",
+}
+
+type exportController struct {
+	UseCase usecases.ExportUseCase
+}
+
+type ExportController interface {
+	Export(c *gin.Context)
+	ExportBookMarkdown(c *gin.Context)
+}
+
+func NewExportController(dbConnection repositories.DBConnection) ExportController {
+	bookRepo := repositories.NewBookRepository(dbConnection)
+	descRepo := repositories.NewDescriptionRepository(dbConnection)
+	u := usecases.NewExportUseCase(bookRepo, descRepo)
+	return &exportController{UseCase: u}
+}
+
+// Export streams every book of the account as JSON, as CSV with format=csv,
+// or with format=markdown as a zip archive holding one Markdown note per book.
+// The response starts with the first book, so an error before it still gets an error status;
+// an error after it cuts the download short.
+func (e *exportController) Export(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("Export: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	var started bool
+	var err error
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		started, err = e.exportJSON(c, accountId)
+	case "csv":
+		started, err = e.exportCSV(c, accountId)
+	case "markdown":
+		started, err = e.exportMarkdown(c, accountId)
+	default:
+		log.Println("Export: ", errors.New("invalid export format"))
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	if err != nil {
+		log.Println("Export: ", err.Error())
+		if !started {
+			errorResponse(c, err)
+		}
+	}
+}
+
+// ExportBookMarkdown downloads the book as a Markdown note with its descriptions.
+func (e *exportController) ExportBookMarkdown(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("ExportBookMarkdown: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("ExportBookMarkdown: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+
+	book, err := e.UseCase.GetBookNotes(filter)
+	if err != nil {
+		log.Println("ExportBookMarkdown: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.Header("Content-Type", "text/markdown; charset=utf-8")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": markdownFileName(*book)}))
+	c.Status(http.StatusOK)
+	err = writeBookMarkdown(c.Writer, *book)
+	if err != nil {
+		log.Println("ExportBookMarkdown: ", err.Error())
+	}
+}
+
+func exportHeader(c *gin.Context, contentType string, extension string) {
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="bookshelf-%s.%s"`, time.Now().Format("20060102"), extension))
+	c.Status(http.StatusOK)
+}
+
+func (e *exportController) exportJSON(c *gin.Context, accountId string) (bool, error) {
+	started := false
+	begin := func() error {
+		started = true
+		exportHeader(c, "application/json; charset=utf-8", "json")
+		exportedAt, err := json.Marshal(time.Now())
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(c.Writer, `{"version":%d,"exported_at":%s,"books":[`, domain.ExportVersion, exportedAt)
+		return err
+	}
+
+	err := e.UseCase.ExportBooks(accountId, func(book domain.ExportedBook) error {
+		separator := ","
+		if !started {
+			separator = ""
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		data, err := json.Marshal(book)
+		if err != nil {
+			return err
+		}
+		_, err = c.Writer.WriteString(separator + string(data))
+		return err
+	})
+	if err != nil {
+		return started, err
+	}
+	if !started {
+		if err := begin(); err != nil {
+			return started, err
+		}
+	}
+	_, err = c.Writer.WriteString("]}")
+	return started, err
+}
+
+func (e *exportController) exportCSV(c *gin.Context, accountId string) (bool, error) {
+	started := false
+	writer := csv.NewWriter(c.Writer)
+	begin := func() error {
+		started = true
+		exportHeader(c, "text/csv; charset=utf-8", "csv")
+		return writer.Write(exportColumns)
+	}
+
+	err := e.UseCase.ExportBooks(accountId, func(book domain.ExportedBook) error {
+		if !started {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		r := usecases.NewImportRecord(0, book)
+		tags, err := json.Marshal(book.Tags)
+		if err != nil {
+			return err
+		}
+		descriptions, err := json.Marshal(book.Descriptions)
+		if err != nil {
+			return err
+		}
+		return writer.Write([]string{
+			r.Title, r.Author, r.Status, r.ReadCount, r.StartAt, r.EndAt, r.Rating,
+			r.Review, r.ISBN, r.ISBN13, r.TotalPages, r.Publisher, r.PublishedDate, book.CoverURL,
+			string(tags), string(descriptions),
+		})
+	})
+	if err != nil {
+		return started, err
+	}
+	if !started {
+		if err := begin(); err != nil {
+			return started, err
+		}
+	}
+	writer.Flush()
+	return started, writer.Error()
+}
+
+func (e *exportController) exportMarkdown(c *gin.Context, accountId string) (bool, error) {
+	started := false
+	archive := zip.NewWriter(c.Writer)
+	names := map[string]bool{}
+
+	err := e.UseCase.ExportNotes(accountId, func(book domain.Book) error {
+		if !started {
+			started = true
+			exportHeader(c, "application/zip", "zip")
+		}
+		// books sharing a title get their id added to the file name
+		name := markdownFileName(book)
+		if names[name] {
+			name = fmt.Sprintf("%s %d.md", strings.TrimSuffix(name, ".md"), book.ID)
+		}
+		names[name] = true
+
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: book.UpdatedAt})
+		if err != nil {
+			return err
+		}
+		return writeBookMarkdown(w, book)
+	})
+	if err != nil {
+		return started, err
+	}
+	if !started {
+		started = true
+		exportHeader(c, "application/zip", "zip")
+	}
+	return started, archive.Close()
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportUseCaseStub exports the same books for every account.
+type exportUseCaseStub struct {
+	usecases.ExportUseCase
+	Books domain.ExportedBooks
+}
+
+func (e exportUseCaseStub) ExportBooks(accountId string, write func(book domain.ExportedBook) error) error {
+	for _, v := range e.Books {
+		if err := write(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportCSVCoverURL(t *testing.T) {
+	withCover := domain.Book{Title: "Dune", ReadState: domain.NotReadValue, CoverURL: domain.CoverURL(12, "1a2b3c")}
+	withoutCover := domain.Book{Title: "Emma", ReadState: domain.NotReadValue}
+	e := exportController{UseCase: exportUseCaseStub{Books: domain.ExportedBooks{
+		domain.NewExportedBook(withCover, nil),
+		domain.NewExportedBook(withoutCover, nil),
+	}}}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("account_id", "account-a")
+	})
+	router.GET("/export", e.Export)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export?format=csv", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d: %s", w.Code, w.Body.String())
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	column := -1
+	for i, v := range rows[0] {
+		if v == "cover_url" {
+			column = i
+		}
+	}
+	if column < 0 || len(rows) != 3 {
+		t.Fatalf("got header %v and %d rows", rows[0], len(rows))
+	}
+	if rows[1][column] != "/book/12/cover?v=1a2b3c" || rows[2][column] != "" {
+		t.Errorf("got cover urls %q and %q", rows[1][column], rows[2][column])
+	}
+
+	// the column is read back without complaint, and without a cover
+	records, err := readCSVRecords(strings.NewReader(w.Body.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Title != "Dune" {
+		t.Errorf("got records %+v", records)
+	}
+}
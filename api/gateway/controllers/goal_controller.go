@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type goalController struct {
+	UseCase usecases.GoalUseCase
+}
+
+type GoalController interface {
+	GetAllGoals(c *gin.Context)
+	GetGoal(c *gin.Context)
+	SetGoal(c *gin.Context)
+	DeleteGoal(c *gin.Context)
+}
+
+func NewGoalController(dbConnection repositories.DBConnection) GoalController {
+	goalRepo := repositories.NewGoalRepository(dbConnection)
+	statsRepo := repositories.NewStatsRepository(dbConnection)
+	u := usecases.NewGoalUseCase(goalRepo, statsRepo)
+	return &goalController{UseCase: u}
+}
+
+type GoalForm struct {
+	Target uint `json:"target" binding:"required"`
+}
+
+func (g *goalController) GetAllGoals(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetAllGoals: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	goals, err := g.UseCase.GetAllGoals(accountId)
+	if err != nil {
+		log.Println("GetAllGoals: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: goals})
+}
+
+func (g *goalController) GetGoal(c *gin.Context) {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		log.Println("GetGoal: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetGoal: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	goal, err := g.UseCase.GetGoal(accountId, year)
+	if err != nil {
+		log.Println("GetGoal: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: goal})
+}
+
+func (g *goalController) SetGoal(c *gin.Context) {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		log.Println("SetGoal: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("SetGoal: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	form := GoalForm{}
+	err = c.ShouldBind(&form)
+	if err != nil {
+		log.Println("SetGoal: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	goal := domain.Goal{
+		AccountID: accountId,
+		Year:      year,
+		Target:    form.Target,
+	}
+
+	progress, err := g.UseCase.SetGoal(goal)
+	if err != nil {
+		log.Println("SetGoal: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: progress})
+}
+
+func (g *goalController) DeleteGoal(c *gin.Context) {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		log.Println("DeleteGoal: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("DeleteGoal: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	err = g.UseCase.DeleteGoal(accountId, year)
+	if err != nil {
+		log.Println("DeleteGoal: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
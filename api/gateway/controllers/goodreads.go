@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+var errNotGoodreadsExport = errors.New("file is not a Goodreads library export")
+
+// goodreadsShelves maps the exclusive shelves of Goodreads onto read states.
+// Custom exclusive shelves are treated as not read.
+var goodreadsShelves = map[string]string{
+	"to-read":           "not_read",
+	"currently-reading": "reading",
+	"read":              "read",
+}
+
+var goodreadsReviewReplacer = strings.NewReplacer("<br/>", "\n", "<br />", "\n", "<br>", "\n")
+
+// readGoodreadsRecords reads the library export CSV of Goodreads.
+// Goodreads keeps no start date, so the date a book was added to the shelf stands in for it.
+func readGoodreadsRecords(r io.Reader) ([]usecases.ImportRecord, error) {
+	columns, rows, err := readCSVRows(r)
+	if err != nil {
+		return nil, err
+	}
+	hasShelf := false
+	for _, v := range columns {
+		hasShelf = hasShelf || v == "exclusive_shelf"
+	}
+	if !hasShelf {
+		return nil, errNotGoodreadsExport
+	}
+
+	records := make([]usecases.ImportRecord, 0, len(rows))
+	for _, row := range rows {
+		f := row.Fields
+		status, ok := goodreadsShelves[strings.TrimSpace(f["exclusive_shelf"])]
+		if !ok {
+			status = "not_read"
+		}
+		record := usecases.ImportRecord{
+			Line:   row.Line,
+			Title:  f["title"],
+			Author: f["author"],
+			Status: status,
+			Review: goodreadsReviewReplacer.Replace(f["my_review"]),
+			Notes:  f["private_notes"],
+			ISBN:   goodreadsISBN(f["isbn"]),
+			ISBN13: goodreadsISBN(f["isbn13"]),
+		}
+		if rating := strings.TrimSpace(f["my_rating"]); rating != "0" {
+			record.Rating = rating
+		}
+
+		added := strings.TrimSpace(f["date_added"])
+		read := strings.TrimSpace(f["date_read"])
+		switch status {
+		case "reading":
+			record.StartAt = added
+		case "read":
+			if read == "" {
+				read = added
+			}
+			// both dates are yyyy/mm/dd, so they compare as strings
+			record.StartAt = added
+			if read < added {
+				record.StartAt = read
+			}
+			record.EndAt = read
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// goodreadsISBN strips the spreadsheet formula Goodreads wraps ISBNs in, as in ="0374533555".
+func goodreadsISBN(s string) string {
+	return strings.Trim(strings.TrimSpace(s), `="`)
+}
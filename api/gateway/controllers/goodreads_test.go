@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+// goodreadsHeader is the header row of a Goodreads library export.
+const goodreadsHeader = "Book Id,Title,Author,Author l-f,Additional Authors,ISBN,ISBN13,My Rating,Average Rating,Publisher,Binding,Number of Pages,Year Published,Original Publication Year,Date Read,Date Added,Bookshelves,Bookshelves with positions,Exclusive Shelf,My Review,Spoiler,Private Notes,Read Count,Owned Copies"
+
+func TestReadGoodreadsRecords(t *testing.T) {
+	rows := []string{
+		goodreadsHeader,
+		`234225,"Dune (Dune, #1)",Frank Herbert,"Herbert, Frank",,"=""0441172717""","=""9780441172719""",5,4.27,Ace Books,Mass Market Paperback,535,1990,1965,2019/03/10,2019/01/02,,,read,"Slow start.<br/><br/>Then it never lets go.",,reread the appendix,1,0`,
+		`11,The Hitchhiker's Guide to the Galaxy,Douglas Adams,"Adams, Douglas",,"=""""","=""""",4,4.22,Del Rey,Paperback,216,1995,1979,2012/06/01,2020/05/17,,,read,,,,1,0`,
+		`5107,The Catcher in the Rye,J.D. Salinger,"Salinger, J.D.",,"=""0316769177""","=""9780316769174""",0,3.80,Back Bay Books,Paperback,277,2001,1951,,2021/02/14,,,read,,,,1,0`,
+		`7624,Lord of the Flies,William Golding,"Golding, William",,"=""0140283331""","=""9780140283334""",0,3.69,Penguin Books,Paperback,182,1999,1954,,2023/09/01,currently-reading,currently-reading (#1),currently-reading,,,,0,0`,
+		`13079982,Fahrenheit 451,Ray Bradbury,"Bradbury, Ray",,"=""""","=""""",0,3.97,Simon & Schuster,Paperback,159,2012,1953,,2024/01/20,to-read,to-read (#3),to-read,,,,0,0`,
+		`4671,The Great Gatsby,F. Scott Fitzgerald,"Fitzgerald, F. Scott",,"=""0743273567""","=""9780743273565""",3,3.93,Scribner,Paperback,180,2004,1925,,2022/11/05,did-not-finish,did-not-finish (#1),did-not-finish,,,,0,0`,
+	}
+	records, err := readGoodreadsRecords(strings.NewReader(strings.Join(rows, "\n") + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []usecases.ImportRecord{
+		{Line: 2, Title: "Dune (Dune, #1)", Author: "Frank Herbert", Status: "read", StartAt: "2019/01/02", EndAt: "2019/03/10",
+			Rating: "5", Review: "Slow start.\n\nThen it never lets go.", Notes: "reread the appendix", ISBN: "0441172717", ISBN13: "9780441172719"},
+		// a book read before it was added keeps its read date as the start
+		{Line: 3, Title: "The Hitchhiker's Guide to the Galaxy", Author: "Douglas Adams", Status: "read", StartAt: "2012/06/01", EndAt: "2012/06/01",
+			Rating: "4"},
+		// a read book without a read date was read when it was added
+		{Line: 4, Title: "The Catcher in the Rye", Author: "J.D. Salinger", Status: "read", StartAt: "2021/02/14", EndAt: "2021/02/14",
+			ISBN: "0316769177", ISBN13: "9780316769174"},
+		{Line: 5, Title: "Lord of the Flies", Author: "William Golding", Status: "reading", StartAt: "2023/09/01",
+			ISBN: "0140283331", ISBN13: "9780140283334"},
+		{Line: 6, Title: "Fahrenheit 451", Author: "Ray Bradbury", Status: "not_read"},
+		// custom exclusive shelves are not read
+		{Line: 7, Title: "The Great Gatsby", Author: "F. Scott Fitzgerald", Status: "not_read", Rating: "3",
+			ISBN: "0743273567", ISBN13: "9780743273565"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(records), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(records[i], want[i]) {
+			t.Errorf("line %d:\ngot  %+v\nwant %+v", want[i].Line, records[i], want[i])
+		}
+	}
+}
+
+func TestReadGoodreadsRecordsNotGoodreads(t *testing.T) {
+	_, err := readGoodreadsRecords(strings.NewReader("title,author\nDune,Frank Herbert\n"))
+	if err != errNotGoodreadsExport {
+		t.Fatalf("got %v, want errNotGoodreadsExport", err)
+	}
+}
+
+func TestGoodreadsISBN(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`="0441172717"`, "0441172717"},
+		{`="9780441172719"`, "9780441172719"},
+		{`=""`, ""},
+		{` ="044117271X" `, "044117271X"},
+		{"0441172717", "0441172717"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := goodreadsISBN(tt.in); got != tt.want {
+			t.Errorf("goodreadsISBN(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type highlightController struct {
+	UseCase usecases.HighlightUseCase
+}
+
+type HighlightController interface {
+	GetAllHighlights(c *gin.Context)
+	GetHighlight(c *gin.Context)
+	CreateHighlight(c *gin.Context)
+	UpdateHighlight(c *gin.Context)
+	PatchHighlight(c *gin.Context)
+	DeleteHighlight(c *gin.Context)
+}
+
+func NewHighlightController(dbConnection repositories.DBConnection) HighlightController {
+	highlightRepo := repositories.NewHighlightRepository(dbConnection)
+	bookRepo := repositories.NewBookRepository(dbConnection)
+	u := usecases.NewHighlightUseCase(highlightRepo, bookRepo)
+	return &highlightController{UseCase: u}
+}
+
+// HighlightForm describes a highlight; location_kind defaults to "page"
+// and end_position to start_position.
+type HighlightForm struct {
+	Quote         string `json:"quote" binding:"required"`
+	LocationKind  string `json:"location_kind"`
+	StartPosition uint   `json:"start_position" binding:"required"`
+	EndPosition   uint   `json:"end_position"`
+	Color         string `json:"color"`
+	Comment       string `json:"comment"`
+}
+
+func (f HighlightForm) toModel(bookId uint64) domain.Highlight {
+	kind := domain.LocationKind(strings.ToLower(f.LocationKind))
+	if kind == "" {
+		kind = domain.PageLocation
+	}
+	endPosition := f.EndPosition
+	if endPosition == 0 {
+		endPosition = f.StartPosition
+	}
+	return domain.Highlight{
+		BookId:        bookId,
+		Quote:         strings.TrimSpace(f.Quote),
+		LocationKind:  kind,
+		StartPosition: f.StartPosition,
+		EndPosition:   endPosition,
+		Color:         strings.ToLower(f.Color),
+		Comment:       f.Comment,
+	}
+}
+
+// HighlightUpdateForm changes only the fields it is sent with.
+// Moving start_position of a single page or location highlight moves its end_position along,
+// unless end_position is sent too.
+type HighlightUpdateForm struct {
+	Quote         *string `json:"quote"`
+	LocationKind  *string `json:"location_kind"`
+	StartPosition *uint   `json:"start_position"`
+	EndPosition   *uint   `json:"end_position"`
+	Color         *string `json:"color"`
+	Comment       *string `json:"comment"`
+}
+
+func (f HighlightUpdateForm) apply(highlight *domain.Highlight) {
+	if f.Quote != nil {
+		highlight.Quote = strings.TrimSpace(*f.Quote)
+	}
+	if f.LocationKind != nil {
+		highlight.LocationKind = domain.LocationKind(strings.ToLower(*f.LocationKind))
+	}
+	if f.StartPosition != nil {
+		if f.EndPosition == nil && highlight.EndPosition == highlight.StartPosition {
+			highlight.EndPosition = *f.StartPosition
+		}
+		highlight.StartPosition = *f.StartPosition
+	}
+	if f.EndPosition != nil {
+		highlight.EndPosition = *f.EndPosition
+	}
+	if f.Color != nil {
+		highlight.Color = strings.ToLower(*f.Color)
+	}
+	if f.Comment != nil {
+		highlight.Comment = *f.Comment
+	}
+}
+
+// highlightFilter reads the book and highlight ids from the path.
+func highlightFilter(c *gin.Context) (*usecases.Filter, error) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	highlightId, err := strconv.ParseUint(c.Param("highlight_id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	filter := usecases.NewFilter()
+	usecases.ById(filter, highlightId)
+	usecases.ByBookId(filter, bookId)
+	return filter, nil
+}
+
+func (h *highlightController) GetAllHighlights(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("GetAllHighlights: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetAllHighlights: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	highlights, err := h.UseCase.GetAllHighlights(bookId, accountId)
+	if err != nil {
+		log.Println("GetAllHighlights: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: highlights})
+}
+
+func (h *highlightController) GetHighlight(c *gin.Context) {
+	filter, err := highlightFilter(c)
+	if err != nil {
+		log.Println("GetHighlight: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetHighlight: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	highlight, err := h.UseCase.GetHighlight(filter, accountId)
+	if err != nil {
+		log.Println("GetHighlight: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: highlight})
+}
+
+func (h *highlightController) CreateHighlight(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("CreateHighlight: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("CreateHighlight: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	form := HighlightForm{}
+	err = c.ShouldBind(&form)
+	if err != nil {
+		log.Println("CreateHighlight: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	newHighlight, err := h.UseCase.CreateHighlight(form.toModel(bookId), accountId)
+	if err != nil {
+		log.Println("CreateHighlight: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: newHighlight})
+}
+
+func (h *highlightController) UpdateHighlight(c *gin.Context) {
+	filter, err := highlightFilter(c)
+	if err != nil {
+		log.Println("UpdateHighlight: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("UpdateHighlight: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	form := HighlightForm{}
+	err = c.ShouldBind(&form)
+	if err != nil {
+		log.Println("UpdateHighlight: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	highlight, err := h.UseCase.UpdateHighlight(form.toModel(0), filter, accountId)
+	if err != nil {
+		log.Println("UpdateHighlight: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: highlight})
+}
+
+// PatchHighlight updates the fields of the highlight that are sent and keeps the others.
+func (h *highlightController) PatchHighlight(c *gin.Context) {
+	filter, err := highlightFilter(c)
+	if err != nil {
+		log.Println("PatchHighlight: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("PatchHighlight: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	form := HighlightUpdateForm{}
+	err = c.ShouldBindJSON(&form)
+	if err != nil {
+		log.Println("PatchHighlight: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	highlight, err := h.UseCase.GetHighlight(filter, accountId)
+	if err != nil {
+		log.Println("PatchHighlight: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	form.apply(highlight)
+
+	updatedHighlight, err := h.UseCase.UpdateHighlight(*highlight, filter, accountId)
+	if err != nil {
+		log.Println("PatchHighlight: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: updatedHighlight})
+}
+
+func (h *highlightController) DeleteHighlight(c *gin.Context) {
+	filter, err := highlightFilter(c)
+	if err != nil {
+		log.Println("DeleteHighlight: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("DeleteHighlight: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	err = h.UseCase.DeleteHighlight(filter, accountId)
+	if err != nil {
+		log.Println("DeleteHighlight: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"bookshelf-web-api_gin_clean/api/usecases/usecasetest"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newHighlightRouter serves the highlight routes over book 1 of account-a, which carries highlight 5
+// quoting pages 10 to 12 in yellow.
+func newHighlightRouter() (*gin.Engine, *usecasetest.HighlightRepository) {
+	book := domain.Book{AccountID: "account-a", Title: "Dune", ReadState: domain.NotReadValue}
+	book.ID = 1
+	highlight := domain.Highlight{BookId: 1, Quote: "Fear is the mind-killer.", LocationKind: domain.PageLocation,
+		StartPosition: 10, EndPosition: 12, Color: "yellow", Comment: "litany"}
+	highlight.ID = 5
+	highlightRepo := &usecasetest.HighlightRepository{Highlights: domain.Highlights{highlight}}
+	bookRepo := &usecasetest.BookRepository{Books: domain.Books{book}}
+	h := highlightController{UseCase: usecases.NewHighlightUseCase(highlightRepo, bookRepo)}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("account_id", "account-a")
+	})
+	router.PUT("/book/:id/highlights/:highlight_id", h.UpdateHighlight)
+	router.PATCH("/book/:id/highlights/:highlight_id", h.PatchHighlight)
+	return router, highlightRepo
+}
+
+func TestUpdateHighlight(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		status int
+		start  uint
+		end    uint
+		color  string
+		quote  string
+	}{
+		{"patch comment only", http.MethodPatch, `{"comment": "the litany"}`, http.StatusOK, 10, 12, "yellow", "Fear is the mind-killer."},
+		{"patch color", http.MethodPatch, `{"color": "Blue"}`, http.StatusOK, 10, 12, "blue", "Fear is the mind-killer."},
+		{"patch range", http.MethodPatch, `{"start_position": 20, "end_position": 21}`, http.StatusOK, 20, 21, "yellow", "Fear is the mind-killer."},
+		{"patch start past end", http.MethodPatch, `{"start_position": 20}`, http.StatusBadRequest, 10, 12, "yellow", "Fear is the mind-killer."},
+		{"patch empty quote", http.MethodPatch, `{"quote": " "}`, http.StatusBadRequest, 10, 12, "yellow", "Fear is the mind-killer."},
+		{"put defaults end to start", http.MethodPut, `{"quote": "I must not fear.", "start_position": 30}`, http.StatusOK, 30, 30, "", "I must not fear."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, highlightRepo := newHighlightRouter()
+			req := httptest.NewRequest(tt.method, "/book/1/highlights/5", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Fatalf("got status %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+			got := highlightRepo.Highlights[0]
+			if got.StartPosition != tt.start || got.EndPosition != tt.end || got.Color != tt.color || got.Quote != tt.quote {
+				t.Fatalf("got %+v", got)
+			}
+		})
+	}
+}
+
+func TestPatchSinglePositionHighlight(t *testing.T) {
+	highlight := domain.Highlight{StartPosition: 7, EndPosition: 7}
+	start := uint(9)
+	HighlightUpdateForm{StartPosition: &start}.apply(&highlight)
+	if highlight.StartPosition != 9 || highlight.EndPosition != 9 {
+		t.Fatalf("got %d to %d, want 9 to 9", highlight.StartPosition, highlight.EndPosition)
+	}
+}
@@ -0,0 +1,385 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize caps the size of an uploaded import file.
+const maxImportSize = 10 << 20
+
+var (
+	errMissingTitleColumn = errors.New("import file has no title column")
+	errExportVersion      = errors.New("unsupported export version")
+)
+
+// importColumns maps the accepted header names, lower-cased, onto the fields of an ImportRecord.
+var importColumns = map[string]string{
+	"title":          "title",
+	"author":         "author",
+	"author_name":    "author",
+	"status":         "status",
+	"read_state":     "status",
+	"read_count":     "read_count",
+	"start":          "start_at",
+	"start_at":       "start_at",
+	"start_date":     "start_at",
+	"date_started":   "start_at",
+	"end":            "end_at",
+	"end_at":         "end_at",
+	"end_date":       "end_at",
+	"date_read":      "end_at",
+	"date_finished":  "end_at",
+	"rating":         "rating",
+	"review":         "review",
+	"notes":          "notes",
+	"note":           "notes",
+	"isbn":           "isbn",
+	"isbn13":         "isbn13",
+	"total_pages":    "total_pages",
+	"pages":          "total_pages",
+	"tags":           "tags",
+	"publisher":      "publisher",
+	"published_date": "published_date",
+	"descriptions":   "descriptions",
+}
+
+type importController struct {
+	UseCase usecases.ImportUseCase
+}
+
+type ImportController interface {
+	ImportCSV(c *gin.Context)
+	ImportGoodreads(c *gin.Context)
+	ImportJSON(c *gin.Context)
+	ImportKindle(c *gin.Context)
+	GetImportJob(c *gin.Context)
+}
+
+func NewImportController(dbConnection repositories.DBConnection) ImportController {
+	deps := newBookUseCaseDeps(dbConnection)
+	bookUseCase := usecases.NewBookUseCase(deps)
+	descRepo := repositories.NewDescriptionRepository(dbConnection)
+	jobRepo := repositories.NewImportJobRepository(dbConnection)
+	descriptionUseCase := usecases.NewDescriptionUseCase(descRepo, deps.BookRepo)
+	tagUseCase := usecases.NewTagUseCase(deps.TagRepo)
+	u := usecases.NewImportUseCase(bookUseCase, descriptionUseCase, tagUseCase, deps.BookRepo, jobRepo)
+	// the jobs a previous run left behind stopped with it
+	err := u.FailInterruptedJobs()
+	if err != nil {
+		log.Println("NewImportController: ", err.Error())
+	}
+	return &importController{UseCase: u}
+}
+
+// importFile opens the uploaded file, sent either as the "file" field of a multipart form or as the request body.
+func importFile(c *gin.Context) (io.ReadCloser, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		return header.Open()
+	}
+	return c.Request.Body, nil
+}
+
+// csvRow is a row of a CSV file by column name. Line counts from 1 and includes the header.
+type csvRow struct {
+	Line   int
+	Fields map[string]string
+}
+
+// readCSVRows reads a CSV file with a header row and returns the column names and the rows.
+// Column names are lower-cased with spaces replaced by underscores.
+func readCSVRows(r io.Reader) ([]string, []csvRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := make([]string, len(header))
+	for i, v := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(v, "\ufeff")))
+		columns[i] = strings.Replace(name, " ", "_", -1)
+	}
+
+	rows := make([]csvRow, 0)
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		row := csvRow{Line: line, Fields: map[string]string{}}
+		for i, v := range fields {
+			if i < len(columns) {
+				row.Fields[columns[i]] = v
+			}
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows, nil
+}
+
+// readCSVRecords reads a CSV file in our own import format. Unknown columns are ignored.
+func readCSVRecords(r io.Reader) ([]usecases.ImportRecord, error) {
+	columns, rows, err := readCSVRows(r)
+	if err != nil {
+		return nil, err
+	}
+	hasTitle := false
+	for _, v := range columns {
+		hasTitle = hasTitle || importColumns[v] == "title"
+	}
+	if !hasTitle {
+		return nil, errMissingTitleColumn
+	}
+
+	records := make([]usecases.ImportRecord, 0, len(rows))
+	for _, row := range rows {
+		values := map[string]string{}
+		for name, v := range row.Fields {
+			if field, ok := importColumns[name]; ok {
+				values[field] = v
+			}
+		}
+		records = append(records, usecases.ImportRecord{
+			Line:          row.Line,
+			Title:         values["title"],
+			Author:        values["author"],
+			Status:        values["status"],
+			ReadCount:     values["read_count"],
+			StartAt:       values["start_at"],
+			EndAt:         values["end_at"],
+			Rating:        values["rating"],
+			Review:        values["review"],
+			Notes:         values["notes"],
+			ISBN:          values["isbn"],
+			ISBN13:        values["isbn13"],
+			TotalPages:    values["total_pages"],
+			Publisher:     values["publisher"],
+			PublishedDate: values["published_date"],
+			Tags:          csvTags(values["tags"]),
+			Descriptions:  csvDescriptions(values["descriptions"]),
+		})
+	}
+	return records, nil
+}
+
+// csvDescriptions reads the descriptions column of a CSV export, a JSON array of descriptions
+// or, from version 1, of strings. Any other text is taken as a single description.
+func csvDescriptions(s string) domain.ExportedDescriptions {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	var descriptions domain.ExportedDescriptions
+	if json.Unmarshal([]byte(s), &descriptions) != nil {
+		return domain.ExportedDescriptions{{Content: s}}
+	}
+	return descriptions
+}
+
+// csvTags reads the tags column of a CSV export, a JSON array of tag names.
+// Any other text is taken as comma separated names.
+func csvTags(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	var tags []string
+	if json.Unmarshal([]byte(s), &tags) != nil {
+		return strings.Split(s, ",")
+	}
+	return tags
+}
+
+// readJSONRecords reads a library exported as JSON.
+func readJSONRecords(r io.Reader) ([]usecases.ImportRecord, error) {
+	export := domain.LibraryExport{}
+	err := json.NewDecoder(r).Decode(&export)
+	if err != nil {
+		return nil, err
+	}
+	if export.Version < 1 || export.Version > domain.ExportVersion {
+		return nil, errExportVersion
+	}
+	records := make([]usecases.ImportRecord, 0, len(export.Books))
+	for i, v := range export.Books {
+		records = append(records, usecases.NewImportRecord(i+1, v))
+	}
+	return records, nil
+}
+
+func (i *importController) ImportCSV(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("ImportCSV: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	file, err := importFile(c)
+	if err != nil {
+		log.Println("ImportCSV: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	defer file.Close()
+
+	records, err := readCSVRecords(file)
+	if err != nil {
+		log.Println("ImportCSV: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	report, err := i.UseCase.ImportBooks(accountId, records)
+	if err != nil {
+		log.Println("ImportCSV: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: report})
+}
+
+// ImportJSON imports a library exported by GET /export?format=json.
+func (i *importController) ImportJSON(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("ImportJSON: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	file, err := importFile(c)
+	if err != nil {
+		log.Println("ImportJSON: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	defer file.Close()
+
+	records, err := readJSONRecords(file)
+	if err != nil {
+		log.Println("ImportJSON: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	report, err := i.UseCase.ImportBooks(accountId, records)
+	if err != nil {
+		log.Println("ImportJSON: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: report})
+}
+
+// ImportKindle stores the highlights and notes of a Kindle "My Clippings.txt" file as descriptions.
+func (i *importController) ImportKindle(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("ImportKindle: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	file, err := importFile(c)
+	if err != nil {
+		log.Println("ImportKindle: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	defer file.Close()
+
+	clippings, err := readKindleClippings(file)
+	if err != nil {
+		log.Println("ImportKindle: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	report, err := i.UseCase.ImportClippings(accountId, clippings)
+	if err != nil {
+		log.Println("ImportKindle: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: report})
+}
+
+// ImportGoodreads starts importing a Goodreads library export in the background
+// and responds with the job, whose progress is read from GetImportJob.
+func (i *importController) ImportGoodreads(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("ImportGoodreads: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	file, err := importFile(c)
+	if err != nil {
+		log.Println("ImportGoodreads: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	defer file.Close()
+
+	records, err := readGoodreadsRecords(file)
+	if err != nil {
+		log.Println("ImportGoodreads: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	job, err := i.UseCase.StartImport(accountId, "goodreads", records)
+	if err != nil {
+		log.Println("ImportGoodreads: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, Response{Content: job})
+}
+
+func (i *importController) GetImportJob(c *gin.Context) {
+	jobId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("GetImportJob: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetImportJob: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, jobId)
+	usecases.ByAccountId(filter, accountId)
+
+	job, err := i.UseCase.GetImportJob(filter)
+	if err != nil {
+		log.Println("GetImportJob: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: job})
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	created := time.Date(2025, 12, 2, 21, 0, 0, 0, time.UTC)
+	book := domain.Book{
+		Title:     "Dune",
+		Author:    &domain.Author{Name: "Frank Herbert"},
+		ReadState: domain.ReadValue,
+		ReadCount: 2,
+		StartAt:   domain.NewNullTime(created),
+		EndAt:     domain.NewNullTime(created.AddDate(0, 0, 20)),
+		Rating:    domain.NewNullFloat(4.5),
+		Tags:      domain.Tags{{Name: "sf"}, {Name: "classics"}},
+
+		Publisher:     "Ace",
+		PublishedDate: "2005-08-02",
+		CoverURL:      domain.CoverURL(12, "1a2b3c"),
+	}
+	notes := domain.Descriptions{
+		{Content: "first note", EditedAt: domain.NewNullTime(created.Add(time.Hour))},
+		{Content: "second note", SourceKey: "3f2a9c"},
+	}
+	notes[0].CreatedAt = created
+	notes[1].CreatedAt = created.Add(2 * time.Hour)
+	exported := domain.NewExportedBook(book, notes)
+
+	data, err := json.Marshal(domain.LibraryExport{Version: domain.ExportVersion, Books: domain.ExportedBooks{exported}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := readJSONRecords(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := records[0]
+	if r.ReadCount != "2" || !reflect.DeepEqual(r.Tags, []string{"sf", "classics"}) {
+		t.Errorf("got read count %q and tags %v", r.ReadCount, r.Tags)
+	}
+	if r.Publisher != book.Publisher || r.PublishedDate != book.PublishedDate {
+		t.Errorf("got publisher %q and published date %q", r.Publisher, r.PublishedDate)
+	}
+	if len(r.Descriptions) != 2 {
+		t.Fatalf("got descriptions %+v", r.Descriptions)
+	}
+	for i, v := range r.Descriptions {
+		if v.Content != notes[i].Content || !v.CreatedAt.Equal(notes[i].CreatedAt) || v.EditedAt.Valid != notes[i].EditedAt.Valid ||
+			v.SourceKey != notes[i].SourceKey {
+			t.Errorf("description %d: got %+v, want %+v", i, v, notes[i])
+		}
+	}
+	if !r.Descriptions[0].EditedAt.Time.Equal(notes[0].EditedAt.Time) {
+		t.Errorf("got edited at %v, want %v", r.Descriptions[0].EditedAt.Time, notes[0].EditedAt.Time)
+	}
+}
+
+func TestReadJSONRecordsVersion1(t *testing.T) {
+	data := `{"version": 1, "books": [{"title": "Dune", "status": "not_read", "descriptions": ["first note"]}]}`
+	records, err := readJSONRecords(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := records[0].Descriptions; len(d) != 1 || d[0].Content != "first note" || !d[0].CreatedAt.IsZero() {
+		t.Fatalf("got descriptions %+v", d)
+	}
+}
+
+func TestCSVDescriptionsAndTags(t *testing.T) {
+	if d := csvDescriptions(`[{"content": "first note", "created_at": "2025-12-02T21:00:00Z"}]`); len(d) != 1 || d[0].CreatedAt.IsZero() {
+		t.Errorf("got descriptions %+v", d)
+	}
+	if d := csvDescriptions(`["first note", "second note"]`); len(d) != 2 || d[1].Content != "second note" {
+		t.Errorf("got version 1 descriptions %+v", d)
+	}
+	if d := csvDescriptions("just some text"); len(d) != 1 || d[0].Content != "just some text" {
+		t.Errorf("got plain descriptions %+v", d)
+	}
+	if tags := csvTags(`["sf", "classics"]`); !reflect.DeepEqual(tags, []string{"sf", "classics"}) {
+		t.Errorf("got tags %v", tags)
+	}
+	if tags := csvTags("sf,classics"); !reflect.DeepEqual(tags, []string{"sf", "classics"}) {
+		t.Errorf("got comma separated tags %v", tags)
+	}
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+// kindleSeparator ends each entry of a My Clippings.txt file.
+const kindleSeparator = "=========="
+
+var (
+	kindlePage     = regexp.MustCompile(`(?i)\bpage\s+([0-9ivxlcdm-]+)`)
+	kindleLocation = regexp.MustCompile(`(?i)\b(?:location|loc\.)\s+([0-9-]+)`)
+	kindleAddedOn  = regexp.MustCompile(`(?i)added on\s+(.+)$`)
+)
+
+// kindleDateLayouts are the timestamp formats of US and UK English Kindles.
+var kindleDateLayouts = []string{
+	"Monday, January 2, 2006 3:04:05 PM",
+	"Monday, 2 January 2006 15:04:05",
+	"Monday, January 2, 2006, 3:04 PM",
+}
+
+// readKindleClippings reads a Kindle "My Clippings.txt" file. Each entry is a title line ending
+// with the author in parentheses, a line describing the clipping, a blank line, the text and a separator.
+// Bookmarks and entries without text are left out.
+func readKindleClippings(r io.Reader) ([]usecases.Clipping, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxImportSize)
+
+	clippings := make([]usecases.Clipping, 0)
+	entry := make([]string, 0)
+	start, line := 1, 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(strings.TrimPrefix(scanner.Text(), "\ufeff"), "\r")
+		if strings.TrimSpace(text) != kindleSeparator {
+			entry = append(entry, text)
+			continue
+		}
+		if clipping, ok := parseKindleEntry(entry); ok {
+			clipping.Line = start
+			clippings = append(clippings, clipping)
+		}
+		entry = entry[:0]
+		start = line + 1
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	// the last entry may not be followed by a separator
+	if clipping, ok := parseKindleEntry(entry); ok {
+		clipping.Line = start
+		clippings = append(clippings, clipping)
+	}
+	return clippings, nil
+}
+
+func parseKindleEntry(lines []string) (usecases.Clipping, bool) {
+	clipping := usecases.Clipping{}
+	if len(lines) < 3 {
+		return clipping, false
+	}
+	clipping.Title, clipping.Author = splitKindleTitle(strings.TrimSpace(lines[0]))
+
+	meta := lines[1]
+	lower := strings.ToLower(meta)
+	switch {
+	case strings.Contains(lower, "bookmark"):
+		return clipping, false
+	case strings.Contains(lower, "note"):
+		clipping.Kind = usecases.NoteClipping
+	default:
+		clipping.Kind = usecases.HighlightClipping
+	}
+	if m := kindlePage.FindStringSubmatch(meta); m != nil {
+		clipping.Page = m[1]
+	}
+	if m := kindleLocation.FindStringSubmatch(meta); m != nil {
+		clipping.Location = m[1]
+	}
+	if m := kindleAddedOn.FindStringSubmatch(meta); m != nil {
+		for _, layout := range kindleDateLayouts {
+			t, err := time.ParseInLocation(layout, strings.TrimSpace(m[1]), time.Local)
+			if err == nil {
+				clipping.AddedAt = t
+				break
+			}
+		}
+	}
+
+	clipping.Text = strings.TrimSpace(strings.Join(lines[2:], "\n"))
+	return clipping, clipping.Text != ""
+}
+
+// splitKindleTitle splits "Title (Author)" into its parts, turning "Last, First" authors into "First Last".
+func splitKindleTitle(s string) (string, string) {
+	if !strings.HasSuffix(s, ")") {
+		return s, ""
+	}
+	open := strings.LastIndex(s, " (")
+	if open < 0 {
+		return s, ""
+	}
+	title, author := strings.TrimSpace(s[:open]), strings.TrimSpace(s[open+2:len(s)-1])
+	if parts := strings.Split(author, ", "); len(parts) == 2 && !strings.Contains(author, ";") {
+		author = parts[1] + " " + parts[0]
+	}
+	return title, author
+}
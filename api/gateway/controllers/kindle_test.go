@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+func TestParseKindleEntry(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		ok    bool
+		want  usecases.Clipping
+	}{
+		{
+			name: "US highlight with page and location",
+			lines: []string{"Dune (Herbert, Frank)",
+				"- Your Highlight on page 12 | location 170-172 | Added on Sunday, March 3, 2019 10:15:42 PM", "",
+				"I must not fear."},
+			ok: true,
+			want: usecases.Clipping{Title: "Dune", Author: "Frank Herbert", Kind: usecases.HighlightClipping,
+				Page: "12", Location: "170-172", AddedAt: time.Date(2019, 3, 3, 22, 15, 42, 0, time.Local), Text: "I must not fear."},
+		},
+		{
+			name: "UK highlight with location only",
+			lines: []string{"Dune (Frank Herbert)",
+				"- Your Highlight at location 170-172 | Added on Sunday, 3 March 2019 22:15:42", "",
+				"Fear is the mind-killer."},
+			ok: true,
+			want: usecases.Clipping{Title: "Dune", Author: "Frank Herbert", Kind: usecases.HighlightClipping,
+				Location: "170-172", AddedAt: time.Date(2019, 3, 3, 22, 15, 42, 0, time.Local), Text: "Fear is the mind-killer."},
+		},
+		{
+			name: "older US layout note with page only",
+			lines: []string{"Dune (Frank Herbert)",
+				"- Note on Page xii | Added on Sunday, March 3, 2019, 10:15 PM", "",
+				"see the appendix", "on the Bene Gesserit"},
+			ok: true,
+			want: usecases.Clipping{Title: "Dune", Author: "Frank Herbert", Kind: usecases.NoteClipping,
+				Page: "xii", AddedAt: time.Date(2019, 3, 3, 22, 15, 0, 0, time.Local), Text: "see the appendix\non the Bene Gesserit"},
+		},
+		{
+			name:  "unreadable date",
+			lines: []string{"Dune", "- Your Note on Loc. 171 | Added on someday", "", "a note"},
+			ok:    true,
+			want:  usecases.Clipping{Title: "Dune", Kind: usecases.NoteClipping, Location: "171", Text: "a note"},
+		},
+		{
+			name:  "bookmark",
+			lines: []string{"Dune (Frank Herbert)", "- Your Bookmark on page 5 | location 70 | Added on Sunday, March 3, 2019 10:15:42 PM", "", ""},
+		},
+		{
+			name:  "highlight without text",
+			lines: []string{"Dune (Frank Herbert)", "- Your Highlight on page 5 | Added on Sunday, March 3, 2019 10:15:42 PM", "", "  "},
+		},
+		{
+			name:  "too short",
+			lines: []string{"Dune (Frank Herbert)", "- Your Highlight on page 5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseKindleEntry(tt.lines)
+			if ok != tt.ok {
+				t.Fatalf("got ok %v, want %v", ok, tt.ok)
+			}
+			if ok && got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitKindleTitle(t *testing.T) {
+	tests := []struct {
+		in, title, author string
+	}{
+		{"Dune (Frank Herbert)", "Dune", "Frank Herbert"},
+		{"Dune (Herbert, Frank)", "Dune", "Frank Herbert"},
+		{"The Hobbit (Illustrated) (Tolkien, J. R. R.)", "The Hobbit (Illustrated)", "J. R. R. Tolkien"},
+		{"Good Omens (Pratchett, Terry;Gaiman, Neil)", "Good Omens", "Pratchett, Terry;Gaiman, Neil"},
+		{"My Notes", "My Notes", ""},
+		{"(Untitled)", "(Untitled)", ""},
+	}
+	for _, tt := range tests {
+		title, author := splitKindleTitle(tt.in)
+		if title != tt.title || author != tt.author {
+			t.Errorf("splitKindleTitle(%q) = %q, %q, want %q, %q", tt.in, title, author, tt.title, tt.author)
+		}
+	}
+}
+
+func TestReadKindleClippingsWithoutTrailingSeparator(t *testing.T) {
+	file := "\ufeffDune (Frank Herbert)\r\n" +
+		"- Your Highlight on page 12 | location 170-172 | Added on Sunday, March 3, 2019 10:15:42 PM\r\n\r\n" +
+		"I must not fear.\r\n" +
+		"==========\r\n" +
+		"Dune (Frank Herbert)\r\n" +
+		"- Your Bookmark on page 5 | location 70 | Added on Sunday, March 3, 2019 10:16:00 PM\r\n\r\n\r\n" +
+		"==========\r\n" +
+		"Dune (Frank Herbert)\r\n" +
+		"- Your Note on page 12 | location 171 | Added on Sunday, March 3, 2019 10:17:00 PM\r\n\r\n" +
+		"the litany"
+	clippings, err := readKindleClippings(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clippings) != 2 {
+		t.Fatalf("got %d clippings, want 2: %+v", len(clippings), clippings)
+	}
+	if clippings[0].Line != 1 || clippings[0].Text != "I must not fear." {
+		t.Errorf("got first clipping %+v", clippings[0])
+	}
+	if clippings[1].Line != 11 || clippings[1].Kind != usecases.NoteClipping || clippings[1].Text != "the litany" {
+		t.Errorf("got last clipping %+v", clippings[1])
+	}
+}
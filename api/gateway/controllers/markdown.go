@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+)
+
+// markdownFileNameReplacer drops the characters file systems and note apps reject in file names.
+var markdownFileNameReplacer = strings.NewReplacer(
+	"/", "-", "\\", "-", ":", " -", "*", "", "?", "", "\"", "", "<", "", ">", "", "|", "-", "#", "", "^", "", "[", "(", "]", ")",
+)
+
+// maxMarkdownFileName keeps file names well below the 255 byte limit of common file systems.
+const maxMarkdownFileName = 120
+
+// writeBookMarkdown renders a book as a Markdown note: YAML front matter with the title, author,
+// state, dates and tags, the title as heading and one section per description, oldest first.
+func writeBookMarkdown(w io.Writer, book domain.Book) error {
+	buf := bufio.NewWriter(w)
+	buf.WriteString("---\n")
+	buf.WriteString("title: " + yamlString(book.Title) + "\n")
+	if book.Author != nil {
+		buf.WriteString("author: " + yamlString(book.Author.Name) + "\n")
+	}
+	buf.WriteString("state: " + book.ReadState.String() + "\n")
+	if book.StartAt.Valid {
+		buf.WriteString("start_at: " + book.StartAt.Time.Format("2006-01-02") + "\n")
+	}
+	if book.EndAt.Valid {
+		buf.WriteString("end_at: " + book.EndAt.Time.Format("2006-01-02") + "\n")
+	}
+	if len(book.Tags) == 0 {
+		buf.WriteString("tags: []\n")
+	} else {
+		buf.WriteString("tags:\n")
+		for _, v := range book.Tags {
+			buf.WriteString("  - " + yamlString(v.Name) + "\n")
+		}
+	}
+	buf.WriteString("---\n\n")
+
+	fmt.Fprintf(buf, "# %s\n", book.Title)
+	for _, v := range book.Descriptions {
+		fmt.Fprintf(buf, "\n## %s\n\n%s\n", v.CreatedAt.Format("2006-01-02 15:04"), strings.TrimSpace(v.Content))
+	}
+	return buf.Flush()
+}
+
+// yamlString quotes a value as a double quoted YAML scalar. A JSON string is a valid one,
+// so quotes, newlines and other special characters in titles come out escaped.
+func yamlString(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
+
+// markdownFileName names the note of a book after its title, falling back to the book id.
+func markdownFileName(book domain.Book) string {
+	name := strings.TrimSpace(markdownFileNameReplacer.Replace(book.Title))
+	name = strings.Trim(name, ".")
+	if len(name) > maxMarkdownFileName {
+		name = name[:maxMarkdownFileName]
+		// drop what is left of a multi-byte character cut in half
+		name = strings.ToValidUTF8(name, "")
+	}
+	if name == "" {
+		name = fmt.Sprintf("book-%d", book.ID)
+	}
+	return name + ".md"
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type metadataController struct {
+	UseCase usecases.MetadataUseCase
+}
+
+type MetadataController interface {
+	LookupISBN(c *gin.Context)
+	FillBookMetadata(c *gin.Context)
+}
+
+func NewMetadataController(dbConnection repositories.DBConnection, provider usecases.BookMetadataProvider) MetadataController {
+	bookUseCase := usecases.NewBookUseCase(newBookUseCaseDeps(dbConnection))
+	u := usecases.NewMetadataUseCase(bookUseCase, provider)
+	return &metadataController{UseCase: u}
+}
+
+// LookupISBN returns what the metadata provider knows about an ISBN, for filling in a new book.
+func (m *metadataController) LookupISBN(c *gin.Context) {
+	metadata, err := m.UseCase.LookupISBN(c.Param("isbn"))
+	if err != nil {
+		log.Println("LookupISBN: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: metadata})
+}
+
+// FillBookMetadata fills the empty fields of a book from the metadata of its ISBN.
+func (m *metadataController) FillBookMetadata(c *gin.Context) {
+	bookId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("FillBookMetadata: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("FillBookMetadata: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, bookId)
+	usecases.ByAccountId(filter, accountId)
+
+	book, err := m.UseCase.FillBook(filter)
+	if err != nil {
+		log.Println("FillBookMetadata: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: book})
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"strconv"
 	"fmt"
+	"bookshelf-web-api_gin_clean/api/usecases"
 )
 
 func GetPaginate(c *gin.Context) (uint64, uint64, error){
@@ -27,4 +28,32 @@ func GetPaginate(c *gin.Context) (uint64, uint64, error){
 		perPage = tmpPerPage
 	}
 	return page, perPage, nil
-}
\ No newline at end of file
+}
+
+// GetPaging reads page/per_page for offset pagination. A cursor parameter switches to keyset pagination
+// with per_page rows a page; an empty cursor starts from the first row.
+func GetPaging(c *gin.Context) (usecases.Paging, error) {
+	page, perPage, err := GetPaginate(c)
+	if err != nil {
+		return usecases.Paging{}, err
+	}
+	paging := usecases.Paging{Page: page, PerPage: perPage}
+
+	cursorStr, ok := c.GetQuery("cursor")
+	if !ok {
+		return paging, nil
+	}
+	if page > 0 || perPage == 0 {
+		return usecases.Paging{}, fmt.Errorf("GetPaging: cursor needs per_page and no page")
+	}
+	paging.Keyset = true
+	if cursorStr == "" {
+		return paging, nil
+	}
+	cursor, err := usecases.DecodeCursor(cursorStr)
+	if err != nil {
+		return usecases.Paging{}, fmt.Errorf("GetPaging: %s", err)
+	}
+	paging.Cursor = cursor
+	return paging, nil
+}
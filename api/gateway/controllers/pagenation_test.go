@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetPaging(t *testing.T) {
+	cursor := usecases.Cursor{Sort: "title", Values: []string{"Dune", "1"}}.Encode()
+	tests := []struct {
+		query   string
+		page    uint64
+		perPage uint64
+		keyset  bool
+		cursor  bool
+		wantErr bool
+	}{
+		{query: ""},
+		{query: "per_page=10", perPage: 10},
+		{query: "page=2&per_page=10", page: 2, perPage: 10},
+		{query: "per_page=10&cursor=", perPage: 10, keyset: true},
+		{query: "per_page=10&cursor=" + cursor, perPage: 10, keyset: true, cursor: true},
+		{query: "cursor=", wantErr: true},
+		{query: "page=1&per_page=10&cursor=", wantErr: true},
+		{query: "per_page=10&cursor=garbage", wantErr: true},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/books?"+tt.query, nil)
+			paging, err := GetPaging(c)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %+v, want an error", paging)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if paging.Page != tt.page || paging.PerPage != tt.perPage || paging.IsCursor() != tt.keyset || (paging.Cursor != nil) != tt.cursor {
+				t.Fatalf("got %+v", paging)
+			}
+		})
+	}
+}
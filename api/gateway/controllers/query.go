@@ -0,0 +1,18 @@
+package controllers
+
+import "strings"
+
+// splitList reads a comma separated query value, dropping blanks and repeats.
+func splitList(s string) []string {
+	values := make([]string, 0)
+	seen := map[string]bool{}
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ratingController struct {
+	UseCase usecases.RatingUseCase
+}
+
+type RatingController interface {
+	GetAuthorRatings(c *gin.Context)
+	GetTagRatings(c *gin.Context)
+}
+
+func NewRatingController(dbConnection repositories.DBConnection) RatingController {
+	repo := repositories.NewBookRepository(dbConnection)
+	u := usecases.NewRatingUseCase(repo)
+	return &ratingController{UseCase: u}
+}
+
+func (r *ratingController) GetAuthorRatings(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetAuthorRatings: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	stats, err := r.UseCase.GetAuthorRatings(accountId)
+	if err != nil {
+		log.Println("GetAuthorRatings: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: stats})
+}
+
+func (r *ratingController) GetTagRatings(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetTagRatings: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	stats, err := r.UseCase.GetTagRatings(accountId)
+	if err != nil {
+		log.Println("GetTagRatings: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: stats})
+}
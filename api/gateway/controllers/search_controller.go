@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type searchController struct {
+	UseCase usecases.SearchUseCase
+}
+
+type SearchController interface {
+	Search(c *gin.Context)
+}
+
+func NewSearchController(dbConnection repositories.DBConnection) SearchController {
+	bookRepo := repositories.NewBookRepository(dbConnection)
+	descRepo := repositories.NewDescriptionRepository(dbConnection)
+	searchRepo := repositories.NewSearchRepository(dbConnection)
+	u := usecases.NewSearchUseCase(searchRepo, bookRepo, descRepo)
+	return &searchController{UseCase: u}
+}
+
+func (s *searchController) Search(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("Search: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	page, perPage, err := GetPaginate(c)
+	if err != nil {
+		log.Println("GetPaginate: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	hits, err := s.UseCase.Search(accountId, c.Query("q"), page, perPage)
+	if err != nil {
+		log.Println("Search: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: hits})
+}
@@ -0,0 +1,270 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type seriesController struct {
+	UseCase usecases.SeriesUseCase
+}
+
+type SeriesController interface {
+	GetAllSeries(c *gin.Context)
+	GetSeries(c *gin.Context)
+	CreateSeries(c *gin.Context)
+	UpdateSeries(c *gin.Context)
+	DeleteSeries(c *gin.Context)
+	InsertSeriesBook(c *gin.Context)
+	ReorderSeriesBooks(c *gin.Context)
+	RemoveSeriesBook(c *gin.Context)
+	GetNextBooks(c *gin.Context)
+}
+
+func NewSeriesController(dbConnection repositories.DBConnection) SeriesController {
+	seriesRepo := repositories.NewSeriesRepository(dbConnection)
+	bookRepo := repositories.NewBookRepository(dbConnection)
+	u := usecases.NewSeriesUseCase(seriesRepo, bookRepo)
+	return &seriesController{UseCase: u}
+}
+
+type SeriesForm struct {
+	Title string `json:"title" binding:"required"`
+}
+
+type SeriesBookForm struct {
+	BookID   uint64 `json:"book_id" binding:"required"`
+	Position int    `json:"position"`
+}
+
+type SeriesOrderForm struct {
+	BookIDs []uint64 `json:"book_ids"`
+}
+
+// seriesFilter reads the series id from the path and scopes it to the caller.
+func seriesFilter(c *gin.Context) (*usecases.Filter, error) {
+	seriesId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		return nil, errors.New("accountId parser error")
+	}
+	filter := usecases.NewFilter()
+	usecases.ById(filter, seriesId)
+	usecases.ByAccountId(filter, accountId)
+	return filter, nil
+}
+
+func (s *seriesController) GetAllSeries(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetAllSeries: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ByAccountId(filter, accountId)
+
+	seriesList, err := s.UseCase.GetAllSeries(filter)
+	if err != nil {
+		log.Println("GetAllSeries: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: seriesList})
+}
+
+func (s *seriesController) GetSeries(c *gin.Context) {
+	filter, err := seriesFilter(c)
+	if err != nil {
+		log.Println("GetSeries: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	series, err := s.UseCase.GetSeries(filter)
+	if err != nil {
+		log.Println("GetSeries: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: series})
+}
+
+func (s *seriesController) CreateSeries(c *gin.Context) {
+	form := SeriesForm{}
+	err := c.ShouldBind(&form)
+	if err != nil {
+		log.Println("CreateSeries: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("CreateSeries: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	series := domain.Series{
+		AccountID: accountId,
+		Title:     strings.TrimSpace(form.Title),
+	}
+
+	newSeries, err := s.UseCase.CreateSeries(series)
+	if err != nil {
+		log.Println("CreateSeries: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: newSeries})
+}
+
+func (s *seriesController) UpdateSeries(c *gin.Context) {
+	filter, err := seriesFilter(c)
+	if err != nil {
+		log.Println("UpdateSeries: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	form := SeriesForm{}
+	err = c.ShouldBind(&form)
+	if err != nil {
+		log.Println("UpdateSeries: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	series, err := s.UseCase.GetSeries(filter)
+	if err != nil {
+		log.Println("UpdateSeries: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	series.Title = strings.TrimSpace(form.Title)
+
+	updatedSeries, err := s.UseCase.UpdateSeries(*series, filter)
+	if err != nil {
+		log.Println("UpdateSeries: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: updatedSeries})
+}
+
+func (s *seriesController) DeleteSeries(c *gin.Context) {
+	filter, err := seriesFilter(c)
+	if err != nil {
+		log.Println("DeleteSeries: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	err = s.UseCase.DeleteSeries(filter)
+	if err != nil {
+		log.Println("DeleteSeries: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (s *seriesController) InsertSeriesBook(c *gin.Context) {
+	filter, err := seriesFilter(c)
+	if err != nil {
+		log.Println("InsertSeriesBook: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	form := SeriesBookForm{}
+	err = c.ShouldBind(&form)
+	if err != nil {
+		log.Println("InsertSeriesBook: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	series, err := s.UseCase.InsertBook(filter, form.BookID, form.Position)
+	if err != nil {
+		log.Println("InsertSeriesBook: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: series})
+}
+
+func (s *seriesController) ReorderSeriesBooks(c *gin.Context) {
+	filter, err := seriesFilter(c)
+	if err != nil {
+		log.Println("ReorderSeriesBooks: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	form := SeriesOrderForm{}
+	err = c.ShouldBindJSON(&form)
+	if err != nil {
+		log.Println("ReorderSeriesBooks: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	series, err := s.UseCase.ReorderBooks(filter, form.BookIDs)
+	if err != nil {
+		log.Println("ReorderSeriesBooks: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: series})
+}
+
+func (s *seriesController) RemoveSeriesBook(c *gin.Context) {
+	filter, err := seriesFilter(c)
+	if err != nil {
+		log.Println("RemoveSeriesBook: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	bookId, err := strconv.ParseUint(c.Param("book_id"), 10, 64)
+	if err != nil {
+		log.Println("RemoveSeriesBook: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	series, err := s.UseCase.RemoveBook(filter, bookId)
+	if err != nil {
+		log.Println("RemoveSeriesBook: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: series})
+}
+
+func (s *seriesController) GetNextBooks(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetNextBooks: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	books, err := s.UseCase.GetNextBooks(accountId)
+	if err != nil {
+		log.Println("GetNextBooks: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: books})
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type statsController struct {
+	UseCase usecases.StatsUseCase
+}
+
+type StatsController interface {
+	GetStats(c *gin.Context)
+}
+
+func NewStatsController(dbConnection repositories.DBConnection) StatsController {
+	repo := repositories.NewStatsRepository(dbConnection)
+	u := usecases.NewStatsUseCase(repo)
+	return &statsController{UseCase: u}
+}
+
+func (s *statsController) GetStats(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetStats: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	stats, err := s.UseCase.GetStats(accountId)
+	if err != nil {
+		log.Println("GetStats: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: stats})
+}
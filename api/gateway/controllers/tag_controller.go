@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/gateway/repositories"
+	"bookshelf-web-api_gin_clean/api/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type tagController struct {
+	UseCase usecases.TagUseCase
+}
+
+type TagController interface {
+	GetAllTags(c *gin.Context)
+	GetTag(c *gin.Context)
+	CreateTag(c *gin.Context)
+	UpdateTag(c *gin.Context)
+	DeleteTag(c *gin.Context)
+}
+
+func NewTagController(dbConnection repositories.DBConnection) TagController {
+	repo := repositories.NewTagRepository(dbConnection)
+	u := usecases.NewTagUseCase(repo)
+	return &tagController{UseCase: u}
+}
+
+type TagForm struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func (t *tagController) GetAllTags(c *gin.Context) {
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetAllTags: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ByAccountId(filter, accountId)
+
+	tags, err := t.UseCase.GetAllTags(filter)
+	if err != nil {
+		log.Println("GetAllTags: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: tags})
+}
+
+func (t *tagController) GetTag(c *gin.Context) {
+	tagId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("GetTag: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("GetTag: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, tagId)
+	usecases.ByAccountId(filter, accountId)
+
+	tag, err := t.UseCase.GetTag(filter)
+	if err != nil {
+		log.Println("GetTag: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: tag})
+}
+
+func (t *tagController) CreateTag(c *gin.Context) {
+	form := TagForm{}
+	err := c.ShouldBind(&form)
+	if err != nil {
+		log.Println("CreateTag: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("CreateTag: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	tag := domain.Tag{
+		AccountID: accountId,
+		Name:      strings.TrimSpace(form.Name),
+	}
+
+	newTag, err := t.UseCase.CreateTag(tag)
+	if err != nil {
+		log.Println("CreateTag: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: newTag})
+}
+
+func (t *tagController) UpdateTag(c *gin.Context) {
+	tagId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("UpdateTag: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("UpdateTag: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+	form := TagForm{}
+	err = c.ShouldBind(&form)
+	if err != nil {
+		log.Println("UpdateTag: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, tagId)
+	usecases.ByAccountId(filter, accountId)
+
+	tag, err := t.UseCase.GetTag(filter)
+	if err != nil {
+		log.Println("UpdateTag: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	tag.Name = strings.TrimSpace(form.Name)
+
+	updatedTag, err := t.UseCase.UpdateTag(*tag, filter)
+	if err != nil {
+		log.Println("UpdateTag: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Response{Content: updatedTag})
+}
+
+func (t *tagController) DeleteTag(c *gin.Context) {
+	tagId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Println("DeleteTag: ", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+	accountId, ok := c.MustGet("account_id").(string)
+	if !ok {
+		log.Println("DeleteTag: ", errors.New("accountId parser error"))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": http.StatusText(http.StatusNotFound)})
+		return
+	}
+
+	filter := usecases.NewFilter()
+	usecases.ById(filter, tagId)
+	usecases.ByAccountId(filter, accountId)
+
+	err = t.UseCase.DeleteTag(filter)
+	if err != nil {
+		log.Println("DeleteTag: ", err.Error())
+		errorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"fmt"
+	"time"
+)
+
+type AuthorRepository struct {
+	Connection DBConnection
+}
+
+func NewAuthorRepository(conn DBConnection) usecases.AuthorRepository {
+	return &AuthorRepository{Connection: conn}
+}
+
+func (a *AuthorRepository) FindAll(filter *usecases.Filter) (*domain.Authors, error) {
+	var authors = make(domain.Authors, 0)
+	err := a.Connection.Select(filter).SortAsc("name").Bind(&authors).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %s", err)
+	}
+	return &authors, nil
+}
+
+func (a *AuthorRepository) Find(filter *usecases.Filter) (*domain.Author, error) {
+	var author = domain.Author{}
+	query := a.Connection.Select(filter).Bind(&author)
+	if query.RecordNotFound() {
+		return nil, usecases.ErrNotFound
+	}
+	err := query.HasError()
+	if err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+func (a *AuthorRepository) Create(author domain.Author) (*domain.Author, error) {
+	err := a.Connection.Create(&author).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("author create: %s", err)
+	}
+	return &author, nil
+}
+
+func (a *AuthorRepository) Delete(filter *usecases.Filter) error {
+	return a.Connection.Transaction(func(tx DBConnection) error {
+		var author = domain.Author{}
+		query := tx.Select(filter).Bind(&author)
+		if query.RecordNotFound() {
+			return usecases.ErrNotFound
+		}
+		err := query.HasError()
+		if err != nil {
+			return err
+		}
+
+		bookFilter := usecases.NewFilter().Eq(usecases.AuthorIdField, author.ID)
+		err = tx.Table(&BookTable{}).Select(bookFilter).Updates(map[string]interface{}{"author_id": nil}).HasError()
+		if err != nil {
+			return fmt.Errorf("Delete: %s", err)
+		}
+		return tx.Delete(&author).HasError()
+	})
+}
+
+func (a *AuthorRepository) Store(author domain.Author) error {
+	author.UpdatedAt = time.Now()
+	return a.Connection.Update(&author).HasError()
+}
@@ -18,12 +18,21 @@ type Base struct {
 }
 type BookTable struct {
 	Base
-	Title     string
-	AccountID string
-	AuthorID  *uint64
-	StartAt   domain.NullTime
-	EndAt     domain.NullTime
-	ReadState domain.ReadState
+	Title         string
+	AccountID     string
+	AuthorID      *uint64
+	StartAt       domain.NullTime
+	EndAt         domain.NullTime
+	ReadState     domain.ReadState
+	ReadCount     uint
+	TotalPages    uint
+	Rating        domain.NullFloat64
+	Review        string
+	ISBN          string
+	ISBN13        string
+	Publisher     string
+	PublishedDate string
+	Cover         string
 }
 
 func (BookTable) TableName() string {
@@ -31,12 +40,25 @@ func (BookTable) TableName() string {
 }
 func (b *BookTable) ToModel() domain.Book {
 	m := domain.Book{
-		AccountID: b.AccountID,
-		Title:     b.Title,
-		Author:    nil,
-		StartAt:   b.StartAt,
-		EndAt:     b.EndAt,
-		ReadState: b.ReadState,
+		AccountID:     b.AccountID,
+		Title:         b.Title,
+		Author:        nil,
+		StartAt:       b.StartAt,
+		EndAt:         b.EndAt,
+		ReadState:     b.ReadState,
+		ReadCount:     b.ReadCount,
+		TotalPages:    b.TotalPages,
+		Rating:        b.Rating,
+		Review:        b.Review,
+		ISBN:          b.ISBN,
+		ISBN13:        b.ISBN13,
+		Publisher:     b.Publisher,
+		PublishedDate: b.PublishedDate,
+		Cover:         b.Cover,
+		Tags:          domain.Tags{},
+	}
+	if b.Cover != "" {
+		m.CoverURL = domain.CoverURL(b.ID, b.Cover)
 	}
 	m.ID = b.ID
 	m.CreatedAt = b.CreatedAt
@@ -49,12 +71,21 @@ func ToTable(b domain.Book) BookTable {
 		authorID = &b.Author.ID
 	}
 	t := BookTable{
-		Title:     b.Title,
-		AccountID: b.AccountID,
-		AuthorID:  authorID,
-		StartAt:   b.StartAt,
-		EndAt:     b.EndAt,
-		ReadState: b.ReadState,
+		Title:         b.Title,
+		AccountID:     b.AccountID,
+		AuthorID:      authorID,
+		StartAt:       b.StartAt,
+		EndAt:         b.EndAt,
+		ReadState:     b.ReadState,
+		ReadCount:     b.ReadCount,
+		TotalPages:    b.TotalPages,
+		Rating:        b.Rating,
+		Review:        b.Review,
+		ISBN:          b.ISBN,
+		ISBN13:        b.ISBN13,
+		Publisher:     b.Publisher,
+		PublishedDate: b.PublishedDate,
+		Cover:         b.Cover,
 	}
 	t.ID = b.ID
 	t.UpdatedAt = b.UpdatedAt
@@ -62,37 +93,73 @@ func ToTable(b domain.Book) BookTable {
 	return t
 }
 
+func (b *BookTable) cursorValues(sorts usecases.Sorts) []string {
+	values := make([]string, len(sorts))
+	for i, s := range sorts {
+		var v interface{}
+		switch s.Field {
+		case usecases.IdField:
+			v = b.ID
+		case usecases.TitleField:
+			v = b.Title
+		case usecases.ReadStateField:
+			v = int8(b.ReadState)
+		case usecases.ReadCountField:
+			v = b.ReadCount
+		case usecases.RatingField:
+			v = b.Rating
+		case usecases.StartAtField:
+			v = b.StartAt
+		case usecases.EndAtField:
+			v = b.EndAt
+		case usecases.CreatedAtField:
+			v = b.CreatedAt
+		case usecases.UpdatedAtField:
+			v = b.UpdatedAt
+		}
+		values[i] = cursorValue(v)
+	}
+	return values
+}
+
 func NewBookRepository(conn DBConnection) usecases.BookRepository {
 	return &BookRepository{Connection: conn}
 }
 
-func (b *BookRepository) FindAll(filter map[string]interface{}, page uint64, perPage uint64, sortKey string) (*domain.PaginateBooks, error) {
+func (b *BookRepository) FindAll(filter *usecases.Filter, paging usecases.Paging, sorts usecases.Sorts) (*domain.PaginateBooks, error) {
 	var bookTables = make([]BookTable, 0)
 	var count int64 = 0
 	if err := b.Connection.Table(&bookTables).Select(filter).Count(&count).HasError(); err != nil {
 		return nil, err
 	}
 
-	query := b.Connection.Select(filter)
-	if page > 0 && perPage > 0 {
-		query = query.Paginate(page, perPage)
-	}
-	if sortKey == "" {
-		query = query.SortDesc("updated_at")
-	} else {
-		query = query.SortDesc(sortKey)
+	if len(sorts) == 0 {
+		sorts = usecases.Sorts{{Field: usecases.UpdatedAtField, Desc: true}}
 	}
-
-	err := query.Bind(&bookTables).HasError()
+	cursors, err := findPage(b.Connection.Select(filter), sorts, paging, &bookTables, func(i int, sorts usecases.Sorts) []string {
+		return bookTables[i].cursorValues(sorts)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("FindAll: %s", err)
+		return nil, err
 	}
 	var authorTables = domain.Authors{}
-	cc := b.Connection
+	authorIds := make([]uint64, 0)
+	for _, v := range bookTables {
+		if v.AuthorID != nil {
+			authorIds = append(authorIds, *v.AuthorID)
+		}
+	}
+	if len(authorIds) > 0 {
+		err = b.Connection.Select(usecases.NewFilter().In(usecases.IdField, authorIds)).Bind(&authorTables).HasError()
+		if err != nil {
+			return nil, fmt.Errorf("FindAll: %s", err)
+		}
+	}
+	bookIds := make([]uint64, 0, len(bookTables))
 	for _, v := range bookTables {
-		cc.OrFilter(map[string]interface{}{"author_id": v.AuthorID})
+		bookIds = append(bookIds, v.ID)
 	}
-	err = cc.Bind(&authorTables).HasError()
+	tagsByBook, err := findBookTags(b.Connection, bookIds)
 	if err != nil {
 		return nil, fmt.Errorf("FindAll: %s", err)
 	}
@@ -105,30 +172,47 @@ func (b *BookRepository) FindAll(filter map[string]interface{}, page uint64, per
 		} else {
 			b.Author = nil
 		}
+		if tags, ok := tagsByBook[v.ID]; ok {
+			b.Tags = tags
+		}
 		books = append(books, b)
 	}
 
 	paginateBooks := domain.PaginateBooks{
 		Books:      books,
 		TotalCount: count,
+		NextCursor: cursors.Next,
+		PrevCursor: cursors.Prev,
 	}
 
 	return &paginateBooks, nil
 }
 
-func (b *BookRepository) Find(filter map[string]interface{}) (*domain.Book, error) {
+func (b *BookRepository) Find(filter *usecases.Filter) (*domain.Book, error) {
 	var bookTable = BookTable{}
-	err := b.Connection.Select(filter).Bind(&bookTable).HasError()
+	query := b.Connection.Select(filter).Bind(&bookTable)
+	if query.RecordNotFound() {
+		return nil, usecases.ErrNotFound
+	}
+	err := query.HasError()
 	if err != nil {
 		return nil, err
 	}
 
 	book := bookTable.ToModel()
+	tagsByBook, err := findBookTags(b.Connection, []uint64{book.ID})
+	if err != nil {
+		return nil, err
+	}
+	if tags, ok := tagsByBook[book.ID]; ok {
+		book.Tags = tags
+	}
 	if bookTable.AuthorID == nil {
 		return &book, nil
 	}
 	var authorTable = domain.Author{}
-	authorFilter := map[string]interface{}{"id": bookTable.AuthorID}
+	authorFilter := usecases.NewFilter()
+	usecases.ById(authorFilter, *bookTable.AuthorID)
 
 	err = b.Connection.Select(authorFilter).Bind(&authorTable).HasError()
 	if err != nil {
@@ -145,14 +229,53 @@ func (b *BookRepository) Create(book domain.Book) (*domain.Book, error) {
 		return nil, err
 	}
 	newBook := t.ToModel()
+	newBook.Author = book.Author
 	return &newBook, nil
 }
 
-func (b *BookRepository) Delete(filter map[string]interface{}) error {
-	return nil
+func (b *BookRepository) Delete(filter *usecases.Filter) error {
+	return b.Connection.Transaction(func(tx DBConnection) error {
+		var bookTable = BookTable{}
+		query := tx.Select(filter).Bind(&bookTable)
+		if query.RecordNotFound() {
+			return usecases.ErrNotFound
+		}
+		err := query.HasError()
+		if err != nil {
+			return err
+		}
+
+		relationFilter := usecases.NewFilter()
+		usecases.ByBookId(relationFilter, bookTable.ID)
+		err = tx.Select(relationFilter).Delete(&domain.Description{}).HasError()
+		if err != nil {
+			return fmt.Errorf("Delete: %s", err)
+		}
+		err = tx.Select(relationFilter).Delete(&domain.ReadingSession{}).HasError()
+		if err != nil {
+			return fmt.Errorf("Delete: %s", err)
+		}
+		err = tx.Select(relationFilter).Delete(&domain.BookTag{}).HasError()
+		if err != nil {
+			return fmt.Errorf("Delete: %s", err)
+		}
+		err = tx.Select(relationFilter).Delete(&domain.SeriesBook{}).HasError()
+		if err != nil {
+			return fmt.Errorf("Delete: %s", err)
+		}
+		err = tx.Select(relationFilter).Delete(&domain.Highlight{}).HasError()
+		if err != nil {
+			return fmt.Errorf("Delete: %s", err)
+		}
+		err = tx.Select(relationFilter).Delete(&domain.ProgressEntry{}).HasError()
+		if err != nil {
+			return fmt.Errorf("Delete: %s", err)
+		}
+		return tx.Delete(&bookTable).HasError()
+	})
 }
 
-func (b *BookRepository) Store(book domain.Book, filter map[string]interface{}) error {
+func (b *BookRepository) Store(book domain.Book, filter *usecases.Filter) error {
 	t := ToTable(book)
 	t.UpdatedAt = time.Now()
 	return b.Connection.Update(t).HasError()
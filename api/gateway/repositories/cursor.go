@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+// cursorTimeLayout matches how the MySQL driver formats DATETIME values.
+const cursorTimeLayout = "2006-01-02 15:04:05.999999"
+
+// cursorNull stands for a NULL sort key value in a cursor. No formatted time or number starts with a NUL byte.
+const cursorNull = "\x00null"
+
+type pageCursors struct {
+	Next string
+	Prev string
+}
+
+// keysetSorts appends the id tiebreaker that turns sorts into a total order.
+func keysetSorts(sorts usecases.Sorts) usecases.Sorts {
+	for _, s := range sorts {
+		if s.Field == usecases.IdField {
+			return sorts
+		}
+	}
+	desc := false
+	if len(sorts) > 0 {
+		desc = sorts[len(sorts)-1].Desc
+	}
+	full := make(usecases.Sorts, 0, len(sorts)+1)
+	full = append(full, sorts...)
+	return append(full, usecases.Sort{Field: usecases.IdField, Desc: desc})
+}
+
+func reverseSorts(sorts usecases.Sorts) usecases.Sorts {
+	reversed := make(usecases.Sorts, len(sorts))
+	for i, s := range sorts {
+		s.Desc = !s.Desc
+		s.NullsFirst = !s.NullsFirst
+		reversed[i] = s
+	}
+	return reversed
+}
+
+// keysetFilter matches the rows strictly after values in the order of sorts, for example
+// (a > v0) OR (a = v0 AND b > v1) OR (a = v0 AND b = v1 AND id > v2).
+// Nullable keys place NULLs as applySorts does, so a = NULL becomes a IS NULL, and every non-NULL value
+// comes before NULL when NULLs sort last. The id tiebreaker is never NULL, so some group always remains.
+func keysetFilter(sorts usecases.Sorts, values []string) *usecases.Filter {
+	filter := usecases.NewOrFilter()
+	for i, s := range sorts {
+		after := keysetAfter(s, values[i])
+		if after == nil {
+			continue
+		}
+		group := usecases.NewFilter()
+		for j := 0; j < i; j++ {
+			if values[j] == cursorNull {
+				group.IsNull(sorts[j].Field)
+			} else {
+				group.Eq(sorts[j].Field, values[j])
+			}
+		}
+		filter.Group(group.Group(after))
+	}
+	return filter
+}
+
+// keysetAfter matches the values of one key that come strictly after value, or returns nil when none do.
+func keysetAfter(s usecases.Sort, value string) *usecases.Filter {
+	if value == cursorNull {
+		if s.NullsFirst {
+			return usecases.NewFilter().NotNull(s.Field)
+		}
+		return nil
+	}
+	after := usecases.NewOrFilter()
+	if s.Desc {
+		after.Lt(s.Field, value)
+	} else {
+		after.Gt(s.Field, value)
+	}
+	if s.Nullable && !s.NullsFirst {
+		after.IsNull(s.Field)
+	}
+	return after
+}
+
+func cursorValue(v interface{}) string {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Format(cursorTimeLayout)
+	case domain.NullTime:
+		if !t.Valid {
+			return cursorNull
+		}
+		return t.Time.Format(cursorTimeLayout)
+	case domain.NullFloat64:
+		if !t.Valid {
+			return cursorNull
+		}
+		return fmt.Sprint(t.Float64)
+	}
+	return fmt.Sprint(v)
+}
+
+// findPage binds the page of query selected by paging into dest, which must point to a slice.
+// In keyset mode rowValues returns the values of the i-th bound row for each of sorts,
+// and the cursors of the neighbouring pages are returned.
+func findPage(query DBConnection, sorts usecases.Sorts, paging usecases.Paging, dest interface{}, rowValues func(i int, sorts usecases.Sorts) []string) (*pageCursors, error) {
+	cursors := pageCursors{}
+	if !paging.IsCursor() {
+		if paging.Page > 0 && paging.PerPage > 0 {
+			query = query.Paginate(paging.Page, paging.PerPage)
+		}
+		return &cursors, applySorts(query, sorts).Bind(dest).HasError()
+	}
+
+	full := keysetSorts(sorts)
+	backward := false
+	if paging.Cursor != nil {
+		if paging.Cursor.Sort != sorts.String() || len(paging.Cursor.Values) != len(full) {
+			return nil, usecases.ErrInvalidCursor
+		}
+		backward = paging.Cursor.Backward
+	}
+	order := full
+	if backward {
+		order = reverseSorts(full)
+	}
+	if paging.Cursor != nil {
+		query = query.Select(keysetFilter(order, paging.Cursor.Values))
+	}
+
+	// one extra row tells whether another page follows
+	err := applySorts(query, order).Paginate(1, paging.PerPage+1).Bind(dest).HasError()
+	if err != nil {
+		return nil, err
+	}
+	rows := reflect.ValueOf(dest).Elem()
+	hasMore := rows.Len() > int(paging.PerPage)
+	if hasMore {
+		rows.Set(rows.Slice(0, int(paging.PerPage)))
+	}
+	n := rows.Len()
+	if backward {
+		swap := reflect.Swapper(rows.Interface())
+		for i := 0; i < n/2; i++ {
+			swap(i, n-1-i)
+		}
+	}
+	if n == 0 {
+		return &cursors, nil
+	}
+
+	next := usecases.Cursor{Sort: sorts.String(), Values: rowValues(n-1, full)}
+	prev := usecases.Cursor{Sort: sorts.String(), Values: rowValues(0, full), Backward: true}
+	if hasMore || backward {
+		cursors.Next = next.Encode()
+	}
+	if (hasMore && backward) || (!backward && paging.Cursor != nil) {
+		cursors.Prev = prev.Encode()
+	}
+	return &cursors, nil
+}
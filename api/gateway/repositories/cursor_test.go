@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"bookshelf-web-api_gin_clean/api/usecases/usecasetest"
+)
+
+type keysetRow map[usecases.Field]interface{}
+
+// keysetRows have NULLs and duplicates in both nullable keys, so every branch of the keyset predicate is needed.
+var keysetRows = []keysetRow{
+	{usecases.IdField: uint64(1), usecases.TitleField: "b", usecases.EndAtField: "2024-01-02 00:00:00", usecases.RatingField: 4.5},
+	{usecases.IdField: uint64(2), usecases.TitleField: "a", usecases.EndAtField: nil, usecases.RatingField: nil},
+	{usecases.IdField: uint64(3), usecases.TitleField: "c", usecases.EndAtField: "2024-01-02 00:00:00", usecases.RatingField: nil},
+	{usecases.IdField: uint64(4), usecases.TitleField: "a", usecases.EndAtField: "2023-05-01 00:00:00", usecases.RatingField: 3.0},
+	{usecases.IdField: uint64(5), usecases.TitleField: "d", usecases.EndAtField: nil, usecases.RatingField: 4.5},
+	{usecases.IdField: uint64(6), usecases.TitleField: "b", usecases.EndAtField: "2025-03-01 00:00:00", usecases.RatingField: 1.0},
+	{usecases.IdField: uint64(7), usecases.TitleField: "e", usecases.EndAtField: nil, usecases.RatingField: nil},
+}
+
+// compareKeys orders two non-NULL values of the same column.
+func compareKeys(a, b interface{}) int {
+	switch a := a.(type) {
+	case uint64:
+		return int(a) - int(b.(uint64))
+	case float64:
+		if a < b.(float64) {
+			return -1
+		} else if a > b.(float64) {
+			return 1
+		}
+		return 0
+	}
+	as, bs := a.(string), b.(string)
+	if as < bs {
+		return -1
+	} else if as > bs {
+		return 1
+	}
+	return 0
+}
+
+// sortKeysetRows orders rows the way applySorts asks the database to.
+func sortKeysetRows(rows []keysetRow, sorts usecases.Sorts) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, s := range sorts {
+			a, b := rows[i][s.Field], rows[j][s.Field]
+			if a == nil || b == nil {
+				if (a == nil) == (b == nil) {
+					continue
+				}
+				return (a == nil) == s.NullsFirst
+			}
+			c := compareKeys(a, b)
+			if c == 0 {
+				continue
+			}
+			return (c < 0) != s.Desc
+		}
+		return false
+	})
+}
+
+func keysetValues(row keysetRow, sorts usecases.Sorts) []string {
+	values := make([]string, len(sorts))
+	for i, s := range sorts {
+		if row[s.Field] == nil {
+			values[i] = cursorNull
+		} else {
+			values[i] = fmt.Sprint(row[s.Field])
+		}
+	}
+	return values
+}
+
+// walkKeyset reads every row a page at a time in the given order and returns the ids in the order read.
+func walkKeyset(order usecases.Sorts, perPage int) []uint64 {
+	ids := make([]uint64, 0)
+	var values []string
+	for {
+		page := make([]keysetRow, 0)
+		for _, row := range keysetRows {
+			if values == nil || usecasetest.Match(keysetFilter(order, values), row) {
+				page = append(page, row)
+			}
+		}
+		sortKeysetRows(page, order)
+		if len(page) > perPage {
+			page = page[:perPage]
+		}
+		if len(page) == 0 || len(ids) > len(keysetRows) {
+			return ids
+		}
+		for _, row := range page {
+			ids = append(ids, row[usecases.IdField].(uint64))
+		}
+		values = keysetValues(page[len(page)-1], order)
+	}
+}
+
+func TestKeysetFilterWithNullableSorts(t *testing.T) {
+	tests := []string{"-end_at,title", "end_at", "rating,-end_at", "-rating", "-end_at,-rating,title"}
+	for _, sortKey := range tests {
+		t.Run(sortKey, func(t *testing.T) {
+			sorts, err := usecases.ParseSort(sortKey, usecases.BookSortFields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			full := keysetSorts(sorts)
+			all := append([]keysetRow{}, keysetRows...)
+			sortKeysetRows(all, full)
+			want := make([]uint64, 0, len(all))
+			for _, row := range all {
+				want = append(want, row[usecases.IdField].(uint64))
+			}
+
+			for _, perPage := range []int{1, 2, 3} {
+				if got := walkKeyset(full, perPage); !reflect.DeepEqual(got, want) {
+					t.Errorf("forward by %d: got %v, want %v", perPage, got, want)
+				}
+				backward := walkKeyset(reverseSorts(full), perPage)
+				for i, j := 0, len(backward)-1; i < j; i, j = i+1, j-1 {
+					backward[i], backward[j] = backward[j], backward[i]
+				}
+				if !reflect.DeepEqual(backward, want) {
+					t.Errorf("backward by %d: got %v, want %v", perPage, backward, want)
+				}
+			}
+		})
+	}
+}
+
+func TestKeysetNullsSortLast(t *testing.T) {
+	sorts, _ := usecases.ParseSort("-end_at", usecases.BookSortFields)
+	all := append([]keysetRow{}, keysetRows...)
+	sortKeysetRows(all, keysetSorts(sorts))
+	for _, row := range all[:4] {
+		if row[usecases.EndAtField] == nil {
+			t.Fatalf("NULL end_at sorted before a date: %v", all)
+		}
+	}
+}
@@ -1,16 +1,28 @@
 package repositories
 
+import "bookshelf-web-api_gin_clean/api/usecases"
+
 type DBConnection interface {
 	Bind(bind interface{}) DBConnection
-	Select(filter interface{}) DBConnection
+	Select(filter *usecases.Filter) DBConnection
 	Paginate(page, perPage uint64) DBConnection
-	OrFilter(filter interface{}) DBConnection
 	Create(data interface{}) DBConnection
 	Delete(data interface{}) DBConnection
 	Update(data interface{}) DBConnection
+	Updates(values interface{}) DBConnection
 	SortDesc(key string) DBConnection
 	SortAsc(key string) DBConnection
 	Count(count *int64) DBConnection
 	Table(table interface{}) DBConnection
+	// Columns, Group and Scan build aggregate queries; columns may hold SQL expressions with ? placeholders.
+	Columns(columns string, args ...interface{}) DBConnection
+	Group(key string) DBConnection
+	// Join adds a JOIN clause, such as "JOIN books ON books.id = book_tag.book_id", to the query.
+	Join(join string, args ...interface{}) DBConnection
+	Scan(dest interface{}) DBConnection
+	// Raw starts a query from SQL the query builder cannot express, such as a UNION.
+	Raw(sql string, args ...interface{}) DBConnection
+	Transaction(fn func(tx DBConnection) error) error
 	HasError() error
+	RecordNotFound() bool
 }
@@ -5,6 +5,7 @@ import (
 	"bookshelf-web-api_gin_clean/api/usecases"
 	"bookshelf-web-api_gin_clean/api/domain"
 	"fmt"
+	"time"
 )
 
 type DescriptionRepository struct {
@@ -15,24 +16,40 @@ func NewDescriptionRepository(conn DBConnection) usecases.DescriptionRepository
 	return &DescriptionRepository{Connection: conn}
 }
 
-func (d *DescriptionRepository) FindAll(filter map[string]interface{}, page uint64, perPage uint64) (*domain.Descriptions, error) {
+func (d *DescriptionRepository) FindAll(filter *usecases.Filter, paging usecases.Paging) (*domain.PaginateDescriptions, error) {
 	var descriptions = make(domain.Descriptions, 0)
-	if page > 0 && perPage > 0 {
-		err := d.Connection.Select(filter).Paginate(page, perPage).Bind(&descriptions).HasError()
-		if err != nil {
-			return nil, fmt.Errorf("FindAll: %s",err)
-		}
-	} else {
-		err := d.Connection.Select(filter).Bind(&descriptions).HasError()
-		if err != nil {
-			return nil, fmt.Errorf("FindAll: %s",err)
-		}
+	var count int64 = 0
+	err := d.Connection.Table(&descriptions).Select(filter).Count(&count).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %s", err)
 	}
-	return &descriptions, nil
+
+	sorts := usecases.Sorts{{Field: usecases.IdField}}
+	cursors, err := findPage(d.Connection.Select(filter), sorts, paging, &descriptions, func(i int, sorts usecases.Sorts) []string {
+		return []string{cursorValue(descriptions[i].ID)}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PaginateDescriptions{
+		Descriptions: descriptions,
+		TotalCount:   count,
+		NextCursor:   cursors.Next,
+		PrevCursor:   cursors.Prev,
+	}, nil
 }
 
-func (d *DescriptionRepository) Find(filter map[string]interface{}) (*domain.Description, error) {
-	return nil, nil
+func (d *DescriptionRepository) Find(filter *usecases.Filter) (*domain.Description, error) {
+	var description = domain.Description{}
+	query := d.Connection.Select(filter).Bind(&description)
+	if query.RecordNotFound() {
+		return nil, usecases.ErrNotFound
+	}
+	err := query.HasError()
+	if err != nil {
+		return nil, err
+	}
+	return &description, nil
 }
 
 func (d *DescriptionRepository) Create(description domain.Description) (*domain.Description, error) {
@@ -46,3 +63,8 @@ func (d *DescriptionRepository) Create(description domain.Description) (*domain.
 func (d *DescriptionRepository) Delete(description domain.Description) error {
 	return d.Connection.Delete(description).HasError()
 }
+
+func (d *DescriptionRepository) Store(description domain.Description) error {
+	description.UpdatedAt = time.Now()
+	return d.Connection.Update(&description).HasError()
+}
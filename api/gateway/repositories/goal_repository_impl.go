@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+type GoalRepository struct {
+	Connection DBConnection
+}
+
+func NewGoalRepository(conn DBConnection) usecases.GoalRepository {
+	return &GoalRepository{Connection: conn}
+}
+
+// FindAll returns the goals matching filter, latest year first.
+func (g *GoalRepository) FindAll(filter *usecases.Filter) (*domain.Goals, error) {
+	var goals = make(domain.Goals, 0)
+	err := g.Connection.Select(filter).SortDesc("year").Bind(&goals).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %s", err)
+	}
+	return &goals, nil
+}
+
+func (g *GoalRepository) Find(filter *usecases.Filter) (*domain.Goal, error) {
+	var goal = domain.Goal{}
+	query := g.Connection.Select(filter).Bind(&goal)
+	if query.RecordNotFound() {
+		return nil, usecases.ErrNotFound
+	}
+	err := query.HasError()
+	if err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+func (g *GoalRepository) Create(goal domain.Goal) (*domain.Goal, error) {
+	err := g.Connection.Create(&goal).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("goal create: %s", err)
+	}
+	return &goal, nil
+}
+
+func (g *GoalRepository) Delete(goal domain.Goal) error {
+	return g.Connection.Delete(&goal).HasError()
+}
+
+func (g *GoalRepository) Store(goal domain.Goal) error {
+	goal.UpdatedAt = time.Now()
+	return g.Connection.Update(&goal).HasError()
+}
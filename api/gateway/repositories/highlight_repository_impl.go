@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+type HighlightRepository struct {
+	Connection DBConnection
+}
+
+func NewHighlightRepository(conn DBConnection) usecases.HighlightRepository {
+	return &HighlightRepository{Connection: conn}
+}
+
+// highlightSorts orders highlights as they appear in the book, pages before locations.
+var highlightSorts = usecases.Sorts{
+	{Field: usecases.LocationKindField, Desc: true},
+	{Field: usecases.StartPositionField},
+	{Field: usecases.EndPositionField},
+	{Field: usecases.IdField},
+}
+
+func (h *HighlightRepository) FindAll(filter *usecases.Filter) (*domain.Highlights, error) {
+	var highlights = make(domain.Highlights, 0)
+	err := applySorts(h.Connection.Select(filter), highlightSorts).Bind(&highlights).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %s", err)
+	}
+	return &highlights, nil
+}
+
+func (h *HighlightRepository) Find(filter *usecases.Filter) (*domain.Highlight, error) {
+	var highlight = domain.Highlight{}
+	query := h.Connection.Select(filter).Bind(&highlight)
+	if query.RecordNotFound() {
+		return nil, usecases.ErrNotFound
+	}
+	err := query.HasError()
+	if err != nil {
+		return nil, err
+	}
+	return &highlight, nil
+}
+
+func (h *HighlightRepository) Create(highlight domain.Highlight) (*domain.Highlight, error) {
+	err := h.Connection.Create(&highlight).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("highlight create: %s", err)
+	}
+	return &highlight, nil
+}
+
+func (h *HighlightRepository) Delete(highlight domain.Highlight) error {
+	return h.Connection.Delete(&highlight).HasError()
+}
+
+func (h *HighlightRepository) Store(highlight domain.Highlight) error {
+	highlight.UpdatedAt = time.Now()
+	return h.Connection.Update(&highlight).HasError()
+}
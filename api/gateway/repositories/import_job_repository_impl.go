@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+type ImportJobRepository struct {
+	Connection DBConnection
+}
+
+// ImportJobTable keeps the report of a job as JSON.
+type ImportJobTable struct {
+	Base
+	AccountID  string
+	Source     string
+	Status     domain.JobStatus
+	Total      int
+	Processed  int
+	Report     string `sql:"type:longtext"`
+	Error      string
+	FinishedAt domain.NullTime
+}
+
+func (ImportJobTable) TableName() string {
+	return "import_job"
+}
+
+func (j *ImportJobTable) ToModel() (domain.ImportJob, error) {
+	m := domain.ImportJob{
+		AccountID:  j.AccountID,
+		Source:     j.Source,
+		Status:     j.Status,
+		Total:      j.Total,
+		Processed:  j.Processed,
+		Report:     domain.NewImportReport(),
+		Error:      j.Error,
+		FinishedAt: j.FinishedAt,
+	}
+	m.ID = j.ID
+	m.CreatedAt = j.CreatedAt
+	m.UpdatedAt = j.UpdatedAt
+	if j.Report != "" {
+		err := json.Unmarshal([]byte(j.Report), &m.Report)
+		if err != nil {
+			return m, err
+		}
+	}
+	return m, nil
+}
+
+func toImportJobTable(j domain.ImportJob) (ImportJobTable, error) {
+	report, err := json.Marshal(j.Report)
+	if err != nil {
+		return ImportJobTable{}, err
+	}
+	t := ImportJobTable{
+		AccountID:  j.AccountID,
+		Source:     j.Source,
+		Status:     j.Status,
+		Total:      j.Total,
+		Processed:  j.Processed,
+		Report:     string(report),
+		Error:      j.Error,
+		FinishedAt: j.FinishedAt,
+	}
+	t.ID = j.ID
+	t.CreatedAt = j.CreatedAt
+	t.UpdatedAt = j.UpdatedAt
+	return t, nil
+}
+
+func NewImportJobRepository(conn DBConnection) usecases.ImportJobRepository {
+	return &ImportJobRepository{Connection: conn}
+}
+
+func (r *ImportJobRepository) FindAll(filter *usecases.Filter) (*domain.ImportJobs, error) {
+	var jobTables = make([]ImportJobTable, 0)
+	err := r.Connection.Select(filter).Bind(&jobTables).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %s", err)
+	}
+	jobs := make(domain.ImportJobs, 0, len(jobTables))
+	for _, v := range jobTables {
+		job, err := v.ToModel()
+		if err != nil {
+			return nil, fmt.Errorf("FindAll: %s", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return &jobs, nil
+}
+
+func (r *ImportJobRepository) Find(filter *usecases.Filter) (*domain.ImportJob, error) {
+	var jobTable = ImportJobTable{}
+	query := r.Connection.Select(filter).Bind(&jobTable)
+	if query.RecordNotFound() {
+		return nil, usecases.ErrNotFound
+	}
+	err := query.HasError()
+	if err != nil {
+		return nil, err
+	}
+	job, err := jobTable.ToModel()
+	if err != nil {
+		return nil, fmt.Errorf("Find: %s", err)
+	}
+	return &job, nil
+}
+
+func (r *ImportJobRepository) Create(job domain.ImportJob) (*domain.ImportJob, error) {
+	t, err := toImportJobTable(job)
+	if err != nil {
+		return nil, fmt.Errorf("import job create: %s", err)
+	}
+	err = r.Connection.Create(&t).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("import job create: %s", err)
+	}
+	job.ID = t.ID
+	return &job, nil
+}
+
+func (r *ImportJobRepository) Store(job domain.ImportJob) error {
+	t, err := toImportJobTable(job)
+	if err != nil {
+		return fmt.Errorf("import job store: %s", err)
+	}
+	t.UpdatedAt = time.Now()
+	return r.Connection.Update(&t).HasError()
+}
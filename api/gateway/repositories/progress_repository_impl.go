@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"fmt"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+type ProgressRepository struct {
+	Connection DBConnection
+}
+
+func NewProgressRepository(conn DBConnection) usecases.ProgressRepository {
+	return &ProgressRepository{Connection: conn}
+}
+
+// FindAll returns the entries matching filter, oldest first.
+func (p *ProgressRepository) FindAll(filter *usecases.Filter) (*domain.ProgressEntries, error) {
+	var entries = make(domain.ProgressEntries, 0)
+	err := p.Connection.Select(filter).SortAsc("recorded_at").SortAsc("id").Bind(&entries).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %s", err)
+	}
+	return &entries, nil
+}
+
+func (p *ProgressRepository) Create(entry domain.ProgressEntry) (*domain.ProgressEntry, error) {
+	err := p.Connection.Create(&entry).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("progress create: %s", err)
+	}
+	return &entry, nil
+}
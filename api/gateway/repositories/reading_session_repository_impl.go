@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"fmt"
+)
+
+type ReadingSessionRepository struct {
+	Connection DBConnection
+}
+
+func NewReadingSessionRepository(conn DBConnection) usecases.ReadingSessionRepository {
+	return &ReadingSessionRepository{Connection: conn}
+}
+
+func (r *ReadingSessionRepository) FindAll(filter *usecases.Filter) (*domain.ReadingSessions, error) {
+	var sessions = make(domain.ReadingSessions, 0)
+	err := r.Connection.Select(filter).SortAsc("started_at").Bind(&sessions).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %s", err)
+	}
+	return &sessions, nil
+}
+
+// Find returns the most recently started session matching filter.
+func (r *ReadingSessionRepository) Find(filter *usecases.Filter) (*domain.ReadingSession, error) {
+	var session = domain.ReadingSession{}
+	query := r.Connection.Select(filter).SortDesc("started_at").Paginate(1, 1).Bind(&session)
+	if query.RecordNotFound() {
+		return nil, usecases.ErrNotFound
+	}
+	err := query.HasError()
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *ReadingSessionRepository) Create(session domain.ReadingSession) (*domain.ReadingSession, error) {
+	err := r.Connection.Create(&session).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("reading session create: %s", err)
+	}
+	return &session, nil
+}
+
+func (r *ReadingSessionRepository) Store(session domain.ReadingSession) error {
+	return r.Connection.Update(&session).HasError()
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+type SearchRepository struct {
+	Connection DBConnection
+}
+
+func NewSearchRepository(conn DBConnection) usecases.SearchRepository {
+	return &SearchRepository{Connection: conn}
+}
+
+// searchRow is one ranked hit of the search query.
+type searchRow struct {
+	Type          string
+	BookID        uint64
+	DescriptionID uint64
+	Score         float64
+}
+
+// Search scores, ranks and pages the hits in a single query over books, their authors and descriptions.
+func (s *SearchRepository) Search(query usecases.SearchQuery, page, perPage uint64) ([]usecases.SearchMatch, int64, error) {
+	hits, args := searchHitsQuery(query)
+
+	var count = struct{ Count int64 }{}
+	err := s.Connection.Raw("SELECT COUNT(*) AS count FROM ("+hits+") AS hits", args...).Scan(&count).HasError()
+	if err != nil {
+		return nil, 0, fmt.Errorf("Search: %s", err)
+	}
+
+	var rows = make([]searchRow, 0)
+	if count.Count > 0 {
+		ranked := "SELECT type, book_id, description_id, score FROM (" + hits + ") AS hits" +
+			" ORDER BY score DESC, updated_at DESC, book_id DESC, description_id ASC LIMIT ? OFFSET ?"
+		err = s.Connection.Raw(ranked, append(args, perPage, perPage*(page-1))...).Scan(&rows).HasError()
+		if err != nil {
+			return nil, 0, fmt.Errorf("Search: %s", err)
+		}
+	}
+
+	matches := make([]usecases.SearchMatch, 0, len(rows))
+	for _, v := range rows {
+		matches = append(matches, usecases.SearchMatch{
+			Type:          domain.SearchHitType(v.Type),
+			BookID:        v.BookID,
+			DescriptionID: v.DescriptionID,
+			Score:         v.Score,
+		})
+	}
+	return matches, count.Count, nil
+}
+
+// searchHitsQuery unions the matching books and the matching descriptions of the account, each with its score.
+func searchHitsQuery(query usecases.SearchQuery) (string, []interface{}) {
+	titleScore, titleArgs := matchScoreExpr("books.title", query, query.Weights.Title)
+	authorScore, authorArgs := matchScoreExpr("author.name", query, query.Weights.Author)
+	contentScore, contentArgs := matchScoreExpr("description.content", query, query.Weights.Description)
+
+	args := make([]interface{}, 0)
+	args = append(append(append(args, titleArgs...), authorArgs...), query.AccountID)
+	args = append(append(append(args, titleArgs...), authorArgs...), contentArgs...)
+	args = append(append(args, query.AccountID), contentArgs...)
+
+	return "SELECT 'book' AS type, books.id AS book_id, 0 AS description_id, " +
+			titleScore + " + " + authorScore + " AS score, books.updated_at AS updated_at" +
+			" FROM books LEFT JOIN author ON author.id = books.author_id" +
+			" WHERE books.account_id = ? AND " + titleScore + " + " + authorScore + " > 0" +
+			" UNION ALL " +
+			"SELECT 'description' AS type, books.id AS book_id, description.id AS description_id, " +
+			contentScore + " AS score, books.updated_at AS updated_at" +
+			" FROM description JOIN books ON books.id = description.book_id" +
+			" WHERE books.account_id = ? AND " + contentScore + " > 0",
+		args
+}
+
+// matchScoreExpr sums the weight for each term the column contains, and for the whole phrase.
+// The column is lower-cased as the terms are, so matching does not depend on its collation.
+func matchScoreExpr(column string, query usecases.SearchQuery, weight float64) (string, []interface{}) {
+	patterns := make([]string, 0, len(query.Terms)+1)
+	for _, term := range query.Terms {
+		patterns = append(patterns, usecases.Contains(term))
+	}
+	if len(query.Terms) > 1 {
+		patterns = append(patterns, usecases.Contains(query.Phrase))
+	}
+
+	cases := make([]string, 0, len(patterns))
+	args := make([]interface{}, 0, len(patterns)*2)
+	for _, pattern := range patterns {
+		cases = append(cases, fmt.Sprintf("CASE WHEN LOWER(%s) LIKE ? THEN ? ELSE 0 END", column))
+		args = append(args, pattern, weight)
+	}
+	return "(" + strings.Join(cases, " + ") + ")", args
+}
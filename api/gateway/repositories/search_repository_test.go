@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+var searchWeights = usecases.SearchWeights{Title: 3, Author: 2, Description: 1}
+
+func TestSearchHitsQueryPlaceholders(t *testing.T) {
+	tests := []struct {
+		name  string
+		terms []string
+		// patterns per column: one per term, and the phrase for several terms
+		patterns int
+	}{
+		{"one term", []string{"dune"}, 1},
+		{"two terms", []string{"frank", "herbert"}, 3},
+		{"three terms", []string{"the", "mind", "killer"}, 4},
+		{"question mark in a term", []string{"why?", "now"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := usecases.SearchQuery{AccountID: "a", Terms: tt.terms, Phrase: strings.Join(tt.terms, " "), Weights: searchWeights}
+			sql, args := searchHitsQuery(query)
+
+			// title and author twice in the book half, content twice in the description half, each pattern with its weight,
+			// and the account once in each half
+			want := 6*tt.patterns*2 + 2
+			if got := strings.Count(sql, "?"); got != want {
+				t.Errorf("got %d placeholders, want %d", got, want)
+			}
+			if len(args) != want {
+				t.Errorf("got %d args, want %d", len(args), want)
+			}
+		})
+	}
+}
+
+func TestSearchHitsQueryArgs(t *testing.T) {
+	query := usecases.SearchQuery{AccountID: "a", Terms: []string{"50%"}, Phrase: "50%", Weights: searchWeights}
+	sql, args := searchHitsQuery(query)
+
+	title := []interface{}{`%50\%%`, 3.0}
+	author := []interface{}{`%50\%%`, 2.0}
+	content := []interface{}{`%50\%%`, 1.0}
+	want := make([]interface{}, 0)
+	for _, part := range [][]interface{}{title, author, {"a"}, title, author, content, {"a"}, content} {
+		want = append(want, part...)
+	}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %#v,\nwant %#v", args, want)
+	}
+	if !strings.Contains(sql, " UNION ALL ") || strings.Count(sql, "books.account_id = ?") != 2 {
+		t.Errorf("both halves must be limited to the account: %s", sql)
+	}
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"fmt"
+	"time"
+)
+
+type SeriesRepository struct {
+	Connection DBConnection
+}
+
+func NewSeriesRepository(conn DBConnection) usecases.SeriesRepository {
+	return &SeriesRepository{Connection: conn}
+}
+
+func (s *SeriesRepository) FindAll(filter *usecases.Filter) (*domain.SeriesList, error) {
+	var seriesList = make(domain.SeriesList, 0)
+	err := s.Connection.Select(filter).SortAsc("title").Bind(&seriesList).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %s", err)
+	}
+	return &seriesList, nil
+}
+
+func (s *SeriesRepository) Find(filter *usecases.Filter) (*domain.Series, error) {
+	var series = domain.Series{}
+	query := s.Connection.Select(filter).Bind(&series)
+	if query.RecordNotFound() {
+		return nil, usecases.ErrNotFound
+	}
+	err := query.HasError()
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (s *SeriesRepository) Create(series domain.Series) (*domain.Series, error) {
+	err := s.Connection.Create(&series).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("series create: %s", err)
+	}
+	return &series, nil
+}
+
+func (s *SeriesRepository) Delete(filter *usecases.Filter) error {
+	return s.Connection.Transaction(func(tx DBConnection) error {
+		var series = domain.Series{}
+		query := tx.Select(filter).Bind(&series)
+		if query.RecordNotFound() {
+			return usecases.ErrNotFound
+		}
+		err := query.HasError()
+		if err != nil {
+			return err
+		}
+
+		err = tx.Select(usecases.NewFilter().Eq(usecases.SeriesIdField, series.ID)).Delete(&domain.SeriesBook{}).HasError()
+		if err != nil {
+			return fmt.Errorf("Delete: %s", err)
+		}
+		return tx.Delete(&series).HasError()
+	})
+}
+
+func (s *SeriesRepository) Store(series domain.Series) error {
+	series.UpdatedAt = time.Now()
+	return s.Connection.Update(&series).HasError()
+}
+
+// FindMembers returns the memberships matching filter, in reading order.
+func (s *SeriesRepository) FindMembers(filter *usecases.Filter) (*domain.SeriesBooks, error) {
+	var members = make(domain.SeriesBooks, 0)
+	err := s.Connection.Select(filter).SortAsc("series_id").SortAsc("position").Bind(&members).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindMembers: %s", err)
+	}
+	return &members, nil
+}
+
+// StoreMembers replaces the books of the series with bookIds, in that order.
+func (s *SeriesRepository) StoreMembers(seriesId uint64, bookIds []uint64) error {
+	return s.Connection.Transaction(func(tx DBConnection) error {
+		err := tx.Select(usecases.NewFilter().Eq(usecases.SeriesIdField, seriesId)).Delete(&domain.SeriesBook{}).HasError()
+		if err != nil {
+			return fmt.Errorf("StoreMembers: %s", err)
+		}
+		for i, bookId := range bookIds {
+			member := domain.SeriesBook{SeriesId: seriesId, BookId: bookId, Position: uint(i + 1)}
+			err = tx.Create(&member).HasError()
+			if err != nil {
+				return fmt.Errorf("StoreMembers: %s", err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import "bookshelf-web-api_gin_clean/api/usecases"
+
+func applySorts(query DBConnection, sorts usecases.Sorts) DBConnection {
+	for _, s := range sorts {
+		if s.Nullable {
+			// false sorts before true, so NULLs go last unless the order is reversed
+			if s.NullsFirst {
+				query = query.SortDesc(string(s.Field) + " IS NULL")
+			} else {
+				query = query.SortAsc(string(s.Field) + " IS NULL")
+			}
+		}
+		if s.Desc {
+			query = query.SortDesc(string(s.Field))
+		} else {
+			query = query.SortAsc(string(s.Field))
+		}
+	}
+	return query
+}
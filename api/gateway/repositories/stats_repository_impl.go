@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"fmt"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+type StatsRepository struct {
+	Connection DBConnection
+}
+
+func NewStatsRepository(conn DBConnection) usecases.StatsRepository {
+	return &StatsRepository{Connection: conn}
+}
+
+var periodFormats = map[usecases.StatsPeriod]string{
+	usecases.MonthPeriod: "%Y-%m",
+	usecases.YearPeriod:  "%Y",
+}
+
+// rankRow is one group of an aggregate query counting books per author or tag.
+type rankRow struct {
+	Key   uint64
+	Count int64
+}
+
+// FinishedPerPeriod groups the books by the period of their end date, oldest first.
+func (s *StatsRepository) FinishedPerPeriod(filter *usecases.Filter, period usecases.StatsPeriod) (*domain.PeriodStats, error) {
+	format, ok := periodFormats[period]
+	if !ok {
+		return nil, fmt.Errorf("FinishedPerPeriod: unknown period %q", period)
+	}
+	var stats = make(domain.PeriodStats, 0)
+	err := s.Connection.Table(&BookTable{}).Select(filter).
+		Columns("DATE_FORMAT(end_at, ?) AS period, COUNT(*) AS books, COALESCE(SUM(total_pages), 0) AS finished_pages", format).
+		Group("period").SortAsc("period").Scan(&stats).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FinishedPerPeriod: %s", err)
+	}
+	return &stats, nil
+}
+
+func (s *StatsRepository) CountBooks(filter *usecases.Filter) (int64, error) {
+	var count int64 = 0
+	err := s.Connection.Table(&BookTable{}).Select(filter).Count(&count).HasError()
+	if err != nil {
+		return 0, fmt.Errorf("CountBooks: %s", err)
+	}
+	return count, nil
+}
+
+// TopAuthors ranks the authors of the books by how many of them they wrote.
+func (s *StatsRepository) TopAuthors(filter *usecases.Filter, limit uint64) (*domain.RankedStats, error) {
+	var rows = make([]rankRow, 0)
+	authorFilter := usecases.NewFilter().Group(filter).NotNull(usecases.AuthorIdField)
+	err := s.Connection.Table(&BookTable{}).Select(authorFilter).
+		Columns("author_id AS `key`, COUNT(*) AS count").
+		Group("author_id").SortDesc("count").SortAsc("author_id").Paginate(1, limit).Scan(&rows).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("TopAuthors: %s", err)
+	}
+
+	var authors = make(domain.Authors, 0)
+	err = s.Connection.Select(usecases.NewFilter().In(usecases.IdField, rankKeys(rows))).Bind(&authors).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("TopAuthors: %s", err)
+	}
+	names := map[uint64]string{}
+	for _, v := range authors {
+		names[v.ID] = v.Name
+	}
+	return rankedStats(rows, names), nil
+}
+
+// TopTags ranks the tags of the books by how many of them carry the tag.
+// The filter applies to the books joined to book_tag, whose own columns are book_id and tag_id only,
+// so it must not use either of them.
+func (s *StatsRepository) TopTags(filter *usecases.Filter, limit uint64) (*domain.RankedStats, error) {
+	var rows = make([]rankRow, 0)
+	err := s.Connection.Table(&domain.BookTag{}).Join("JOIN books ON books.id = book_tag.book_id").Select(filter).
+		Columns("book_tag.tag_id AS `key`, COUNT(*) AS count").
+		Group("book_tag.tag_id").SortDesc("count").SortAsc("book_tag.tag_id").Paginate(1, limit).Scan(&rows).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("TopTags: %s", err)
+	}
+
+	var tags = make(domain.Tags, 0)
+	err = s.Connection.Select(usecases.NewFilter().In(usecases.IdField, rankKeys(rows))).Bind(&tags).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("TopTags: %s", err)
+	}
+	names := map[uint64]string{}
+	for _, v := range tags {
+		names[v.ID] = v.Name
+	}
+	return rankedStats(rows, names), nil
+}
+
+// FindReadingPeriods returns the reading span of each book that has a start date.
+func (s *StatsRepository) FindReadingPeriods(filter *usecases.Filter) (*domain.ReadingPeriods, error) {
+	var bookTables = make([]BookTable, 0)
+	err := s.Connection.Select(filter).Columns("start_at, end_at, read_state").Bind(&bookTables).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindReadingPeriods: %s", err)
+	}
+	periods := make(domain.ReadingPeriods, 0, len(bookTables))
+	for _, v := range bookTables {
+		if !v.StartAt.Valid {
+			continue
+		}
+		periods = append(periods, domain.ReadingPeriod{StartAt: v.StartAt.Time, EndAt: v.EndAt, ReadState: v.ReadState})
+	}
+	return &periods, nil
+}
+
+func rankKeys(rows []rankRow) []uint64 {
+	keys := make([]uint64, 0, len(rows))
+	for _, v := range rows {
+		keys = append(keys, v.Key)
+	}
+	return keys
+}
+
+func rankedStats(rows []rankRow, names map[uint64]string) *domain.RankedStats {
+	stats := make(domain.RankedStats, 0, len(rows))
+	for _, v := range rows {
+		stats = append(stats, domain.RankedStat{ID: v.Key, Name: names[v.Key], Count: v.Count})
+	}
+	return &stats
+}
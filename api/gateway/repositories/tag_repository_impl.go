@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"fmt"
+	"time"
+)
+
+type TagRepository struct {
+	Connection DBConnection
+}
+
+func NewTagRepository(conn DBConnection) usecases.TagRepository {
+	return &TagRepository{Connection: conn}
+}
+
+func (t *TagRepository) FindAll(filter *usecases.Filter) (*domain.Tags, error) {
+	var tags = make(domain.Tags, 0)
+	err := t.Connection.Select(filter).SortAsc("name").Bind(&tags).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %s", err)
+	}
+	if len(tags) == 0 {
+		return &tags, nil
+	}
+
+	tagIds := make([]uint64, 0, len(tags))
+	for _, v := range tags {
+		tagIds = append(tagIds, v.ID)
+	}
+	var bookTags = make(domain.BookTags, 0)
+	err = t.Connection.Select(usecases.NewFilter().In(usecases.TagIdField, tagIds)).Bind(&bookTags).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindAll: %s", err)
+	}
+	counts := map[uint64]int64{}
+	for _, v := range bookTags {
+		counts[v.TagId]++
+	}
+	for i := range tags {
+		tags[i].BookCount = counts[tags[i].ID]
+	}
+	return &tags, nil
+}
+
+func (t *TagRepository) Find(filter *usecases.Filter) (*domain.Tag, error) {
+	var tag = domain.Tag{}
+	query := t.Connection.Select(filter).Bind(&tag)
+	if query.RecordNotFound() {
+		return nil, usecases.ErrNotFound
+	}
+	err := query.HasError()
+	if err != nil {
+		return nil, err
+	}
+	var count int64 = 0
+	err = t.Connection.Table(&domain.BookTag{}).Select(usecases.NewFilter().Eq(usecases.TagIdField, tag.ID)).Count(&count).HasError()
+	if err != nil {
+		return nil, err
+	}
+	tag.BookCount = count
+	return &tag, nil
+}
+
+func (t *TagRepository) Create(tag domain.Tag) (*domain.Tag, error) {
+	err := t.Connection.Create(&tag).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("tag create: %s", err)
+	}
+	return &tag, nil
+}
+
+func (t *TagRepository) Delete(filter *usecases.Filter) error {
+	return t.Connection.Transaction(func(tx DBConnection) error {
+		var tag = domain.Tag{}
+		query := tx.Select(filter).Bind(&tag)
+		if query.RecordNotFound() {
+			return usecases.ErrNotFound
+		}
+		err := query.HasError()
+		if err != nil {
+			return err
+		}
+
+		err = tx.Select(usecases.NewFilter().Eq(usecases.TagIdField, tag.ID)).Delete(&domain.BookTag{}).HasError()
+		if err != nil {
+			return fmt.Errorf("Delete: %s", err)
+		}
+		return tx.Delete(&tag).HasError()
+	})
+}
+
+func (t *TagRepository) Store(tag domain.Tag) error {
+	tag.UpdatedAt = time.Now()
+	return t.Connection.Update(&tag).HasError()
+}
+
+// FindBookIds returns the books carrying any of tagIds, or all of them when matchAll is set.
+func (t *TagRepository) FindBookIds(tagIds []uint64, matchAll bool) ([]uint64, error) {
+	var bookTags = make(domain.BookTags, 0)
+	err := t.Connection.Select(usecases.NewFilter().In(usecases.TagIdField, tagIds)).Bind(&bookTags).HasError()
+	if err != nil {
+		return nil, fmt.Errorf("FindBookIds: %s", err)
+	}
+
+	wanted := map[uint64]bool{}
+	for _, v := range tagIds {
+		wanted[v] = true
+	}
+	matched := map[uint64]int{}
+	bookIds := make([]uint64, 0)
+	for _, v := range bookTags {
+		if _, ok := matched[v.BookId]; !ok {
+			bookIds = append(bookIds, v.BookId)
+		}
+		matched[v.BookId]++
+	}
+	if !matchAll {
+		return bookIds, nil
+	}
+	allIds := make([]uint64, 0)
+	for _, id := range bookIds {
+		if matched[id] == len(wanted) {
+			allIds = append(allIds, id)
+		}
+	}
+	return allIds, nil
+}
+
+// StoreBookTags replaces the tags of the book with tagIds.
+func (t *TagRepository) StoreBookTags(bookId uint64, tagIds []uint64) error {
+	return t.Connection.Transaction(func(tx DBConnection) error {
+		filter := usecases.NewFilter()
+		usecases.ByBookId(filter, bookId)
+		err := tx.Select(filter).Delete(&domain.BookTag{}).HasError()
+		if err != nil {
+			return fmt.Errorf("StoreBookTags: %s", err)
+		}
+		for _, tagId := range tagIds {
+			err = tx.Create(&domain.BookTag{BookId: bookId, TagId: tagId}).HasError()
+			if err != nil {
+				return fmt.Errorf("StoreBookTags: %s", err)
+			}
+		}
+		return nil
+	})
+}
+
+// findBookTags loads the tags of each of bookIds.
+func findBookTags(conn DBConnection, bookIds []uint64) (map[uint64]domain.Tags, error) {
+	tagsByBook := map[uint64]domain.Tags{}
+	if len(bookIds) == 0 {
+		return tagsByBook, nil
+	}
+	var bookTags = make(domain.BookTags, 0)
+	err := conn.Select(usecases.NewFilter().In(usecases.BookIdField, bookIds)).Bind(&bookTags).HasError()
+	if err != nil {
+		return nil, err
+	}
+	if len(bookTags) == 0 {
+		return tagsByBook, nil
+	}
+
+	tagIds := make([]uint64, 0, len(bookTags))
+	for _, v := range bookTags {
+		tagIds = append(tagIds, v.TagId)
+	}
+	var tags = make(domain.Tags, 0)
+	err = conn.Select(usecases.NewFilter().In(usecases.IdField, tagIds)).SortAsc("name").Bind(&tags).HasError()
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		for _, v := range bookTags {
+			if v.TagId == tag.ID {
+				tagsByBook[v.BookId] = append(tagsByBook[v.BookId], tag)
+			}
+		}
+	}
+	return tagsByBook, nil
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import "bookshelf-web-api_gin_clean/api/usecases"
+
+type Transactor struct {
+	Connection DBConnection
+}
+
+func NewTransactor(conn DBConnection) usecases.Transactor {
+	return &Transactor{Connection: conn}
+}
+
+func (t *Transactor) Transaction(fn func(repos usecases.TxRepositories) error) error {
+	return t.Connection.Transaction(func(tx DBConnection) error {
+		return fn(usecases.TxRepositories{
+			Books:    NewBookRepository(tx),
+			Sessions: NewReadingSessionRepository(tx),
+		})
+	})
+}
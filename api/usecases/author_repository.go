@@ -0,0 +1,11 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type AuthorRepository interface {
+	FindAll(filter *Filter) (*domain.Authors, error)
+	Find(filter *Filter) (*domain.Author, error)
+	Create(author domain.Author) (*domain.Author, error)
+	Delete(filter *Filter) error
+	Store(author domain.Author) error
+}
@@ -0,0 +1,92 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type authorUseCase struct {
+	AuthorRepo AuthorRepository
+}
+type AuthorUseCase interface {
+	GetAllAuthors(filter *Filter) (*domain.Authors, error)
+	GetAuthor(filter *Filter) (*domain.Author, error)
+	CreateAuthor(createAuthor domain.Author) (*domain.Author, error)
+	UpdateAuthor(updateAuthor domain.Author, filter *Filter) (*domain.Author, error)
+	DeleteAuthor(filter *Filter) error
+}
+
+func NewAuthorUseCase(repo AuthorRepository) AuthorUseCase {
+	return &authorUseCase{AuthorRepo: repo}
+}
+
+func (a *authorUseCase) GetAllAuthors(filter *Filter) (*domain.Authors, error) {
+	authors, err := a.AuthorRepo.FindAll(filter)
+	if err != nil {
+		return nil, err
+	}
+	return authors, nil
+}
+
+func (a *authorUseCase) GetAuthor(filter *Filter) (*domain.Author, error) {
+	author, err := a.AuthorRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	return author, nil
+}
+
+func (a *authorUseCase) CreateAuthor(createAuthor domain.Author) (*domain.Author, error) {
+	err := createAuthor.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = a.checkDuplicate(createAuthor)
+	if err != nil {
+		return nil, err
+	}
+	newAuthor, err := a.AuthorRepo.Create(createAuthor)
+	if err != nil {
+		return nil, err
+	}
+	return newAuthor, nil
+}
+
+func (a *authorUseCase) UpdateAuthor(updateAuthor domain.Author, filter *Filter) (*domain.Author, error) {
+	_, err := a.AuthorRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	err = updateAuthor.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = a.checkDuplicate(updateAuthor)
+	if err != nil {
+		return nil, err
+	}
+	err = a.AuthorRepo.Store(updateAuthor)
+	if err != nil {
+		return nil, err
+	}
+	return a.AuthorRepo.Find(filter)
+}
+
+func (a *authorUseCase) DeleteAuthor(filter *Filter) error {
+	return a.AuthorRepo.Delete(filter)
+}
+
+// checkDuplicate rejects a name that another author of the same account already uses.
+func (a *authorUseCase) checkDuplicate(author domain.Author) error {
+	filter := NewFilter()
+	ByAccountId(filter, author.AccountID)
+	ByName(filter, author.Name)
+	found, err := a.AuthorRepo.Find(filter)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if found.ID != author.ID {
+		return ErrAlreadyExists
+	}
+	return nil
+}
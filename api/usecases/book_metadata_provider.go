@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"errors"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+)
+
+// ErrMetadataUnavailable is returned when a metadata provider cannot be reached or gives an unreadable answer.
+var ErrMetadataUnavailable = errors.New("book metadata provider is unavailable")
+
+// BookMetadataProvider looks up an edition by a normalized ISBN-10 or ISBN-13.
+// It returns ErrNotFound for ISBNs it does not know and ErrMetadataUnavailable when it fails.
+type BookMetadataProvider interface {
+	LookupISBN(isbn string) (*domain.BookMetadata, error)
+}
@@ -5,9 +5,9 @@ import (
 )
 
 type BookRepository interface {
-	FindAll(filter map[string]interface{}, page uint64, perPage uint64, sortKey string) (*domain.PaginateBooks, error)
-	Find(filter map[string]interface{}) (*domain.Book, error)
+	FindAll(filter *Filter, paging Paging, sorts Sorts) (*domain.PaginateBooks, error)
+	Find(filter *Filter) (*domain.Book, error)
 	Create(book domain.Book) (*domain.Book, error)
-	Delete(filter map[string]interface{}) error
-	Store(book domain.Book, filter map[string]interface{}) error
+	Delete(filter *Filter) error
+	Store(book domain.Book, filter *Filter) error
 }
@@ -2,55 +2,120 @@ package usecases
 
 import (
 	"bookshelf-web-api_gin_clean/api/domain"
-	"errors"
+	"time"
 )
 
 type bookUseCase struct {
-	BookRepo BookRepository
+	BookRepo     BookRepository
+	AuthorRepo   AuthorRepository
+	SessionRepo  ReadingSessionRepository
+	TagRepo      TagRepository
+	SeriesRepo   SeriesRepository
+	ProgressRepo ProgressRepository
+	Transactor   Transactor
+	Storage      FileStorage
 }
 type BookUseCase interface {
-	GetAllBooks(filter map[string]interface{}, page, parPage uint64, sortKey string) (*domain.PaginateBooks, error) // TODO paging
-	GetBook(filter map[string]interface{}) (*domain.Book, error)
-	UpdateBook(updateBook domain.Book, filter map[string]interface{}) error
+	GetAllBooks(filter *Filter, paging Paging, sorts Sorts) (*domain.PaginateBooks, error)
+	GetBook(filter *Filter) (*domain.Book, error)
+	UpdateBook(updateBook domain.Book, filter *Filter) (*domain.Book, error)
 	CreateBook(createBook domain.Book) (*domain.Book, error)
-	DeleteBook(filter map[string]interface{}) (error)
+	DeleteBook(filter *Filter) (error)
 
-	ChangeStatus(filter map[string]interface{}) error
-	// StoreCategories() error
-	// ChangeRating() error
-	// SetNextBook() error
-	// SetPrevBook() error
+	ChangeStatus(filter *Filter, transition domain.ReadTransition, at *time.Time) (*domain.Book, error)
+	GetSessions(filter *Filter) (*domain.ReadingSessions, error)
+	SetTags(filter *Filter, tagIds []uint64) (*domain.Book, error)
+	FilterByTags(filter *Filter, accountId string, tagNames []string, matchAll bool) error
+	ChangeRating(filter *Filter, rating *domain.NullFloat64, review *string) (*domain.Book, error)
+	LogProgress(filter *Filter, page *uint, percent *float64, at *time.Time) (*domain.Book, error)
+	GetProgress(filter *Filter) (*domain.ProgressEntries, error)
 }
 
-func NewBookUseCase(repo BookRepository) BookUseCase {
-	return &bookUseCase{BookRepo: repo}
+// BookUseCaseDeps are the repositories a BookUseCase works with, and the storage holding cover images.
+// Only BookRepo is needed by every method; the others may be left nil where their methods are not used.
+// Without Storage, DeleteBook leaves the cover images of the book behind.
+type BookUseCaseDeps struct {
+	BookRepo     BookRepository
+	AuthorRepo   AuthorRepository
+	SessionRepo  ReadingSessionRepository
+	TagRepo      TagRepository
+	SeriesRepo   SeriesRepository
+	ProgressRepo ProgressRepository
+	Transactor   Transactor
+	Storage      FileStorage
 }
 
-func (b *bookUseCase) GetAllBooks(filter map[string]interface{}, page, perPage uint64, sortKey string) (*domain.PaginateBooks, error) {
-	books, err := b.BookRepo.FindAll(filter, page, perPage, sortKey)
+func NewBookUseCase(deps BookUseCaseDeps) BookUseCase {
+	return &bookUseCase{
+		BookRepo:     deps.BookRepo,
+		AuthorRepo:   deps.AuthorRepo,
+		SessionRepo:  deps.SessionRepo,
+		TagRepo:      deps.TagRepo,
+		SeriesRepo:   deps.SeriesRepo,
+		ProgressRepo: deps.ProgressRepo,
+		Transactor:   deps.Transactor,
+		Storage:      deps.Storage,
+	}
+}
+
+func (b *bookUseCase) GetAllBooks(filter *Filter, paging Paging, sorts Sorts) (*domain.PaginateBooks, error) {
+	books, err := b.BookRepo.FindAll(filter, paging, sorts)
+	if err != nil {
+		return nil, err
+	}
+	err = b.loadProgress(books.Books)
 	if err != nil {
 		return nil, err
 	}
 	return books, nil
 }
 
-func (b *bookUseCase) GetBook(filter map[string]interface{}) (*domain.Book, error) {
+func (b *bookUseCase) GetBook(filter *Filter) (*domain.Book, error) {
 	book, err := b.BookRepo.Find(filter)
 	if err != nil {
 		return nil, err
 	}
-	return book, nil
+	err = b.loadSeries(book)
+	if err != nil {
+		return nil, err
+	}
+	books := domain.Books{*book}
+	err = b.loadProgress(books)
+	if err != nil {
+		return nil, err
+	}
+	return &books[0], nil
 }
 
-func (b *bookUseCase) UpdateBook(updateBook domain.Book, filter map[string]interface{}) (error) {
-	err := b.BookRepo.Store(updateBook, filter)
+func (b *bookUseCase) UpdateBook(updateBook domain.Book, filter *Filter) (*domain.Book, error) {
+	_, err := b.BookRepo.Find(filter)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	err = updateBook.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = b.resolveAuthor(&updateBook)
+	if err != nil {
+		return nil, err
+	}
+	err = b.BookRepo.Store(updateBook, filter)
+	if err != nil {
+		return nil, err
+	}
+	return b.BookRepo.Find(filter)
 }
 
 func (b *bookUseCase) CreateBook(createBook domain.Book) (*domain.Book, error) {
+	err := createBook.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = b.resolveAuthor(&createBook)
+	if err != nil {
+		return nil, err
+	}
 	newBook, err := b.BookRepo.Create(createBook)
 	if err != nil {
 		return nil, err
@@ -58,34 +123,318 @@ func (b *bookUseCase) CreateBook(createBook domain.Book) (*domain.Book, error) {
 	return newBook, nil
 }
 
-func (b *bookUseCase) DeleteBook(filter map[string]interface{}) (error) {
-	// TODO 関連するdescription、カテゴリなどの削除
-	err := b.BookRepo.Delete(filter)
+// DeleteBook deletes the book with everything attached to it, then its cover images.
+func (b *bookUseCase) DeleteBook(filter *Filter) (error) {
+	book, err := b.BookRepo.Find(filter)
 	if err != nil {
 		return err
 	}
+	err = b.BookRepo.Delete(filter)
+	if err != nil {
+		return err
+	}
+	if book.Cover != "" && b.Storage != nil {
+		deleteCoverFiles(b.Storage, book.ID, book.Cover)
+	}
 	return nil
 }
 
-func (b *bookUseCase) ChangeStatus(filter map[string]interface{}) (error) {
+// ChangeStatus applies transition to the book, dated at the given time or now when at is nil.
+// The book and its reading sessions are stored in one transaction, so they cannot disagree.
+func (b *bookUseCase) ChangeStatus(filter *Filter, transition domain.ReadTransition, at *time.Time) (*domain.Book, error) {
+	transitionAt := time.Now()
+	if at != nil {
+		transitionAt = *at
+	}
+
+	var book *domain.Book
+	err := b.Transactor.Transaction(func(repos TxRepositories) error {
+		found, err := repos.Books.Find(filter)
+		if err != nil {
+			return err
+		}
+		startedAt := found.StartAt
+		err = found.Transition(transition, transitionAt)
+		if err != nil {
+			return err
+		}
+		err = repos.Books.Store(*found, filter)
+		if err != nil {
+			return err
+		}
+		book = found
+		return recordSession(repos.Sessions, found.ID, startedAt, transition, transitionAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+func (b *bookUseCase) GetSessions(filter *Filter) (*domain.ReadingSessions, error) {
 	book, err := b.BookRepo.Find(filter)
 	if err != nil {
+		return nil, err
+	}
+	sessionFilter := NewFilter()
+	ByBookId(sessionFilter, book.ID)
+	return b.SessionRepo.FindAll(sessionFilter)
+}
+
+// recordSession closes the open reading session of the book and, when the book is started, opens a new one.
+// Books started before sessions were recorded get their session back-filled from startedAt.
+func recordSession(sessions ReadingSessionRepository, bookId uint64, startedAt domain.NullTime, transition domain.ReadTransition, at time.Time) error {
+	filter := NewFilter()
+	ByBookId(filter, bookId)
+	ByOutcome(filter, domain.InProgressOutcome)
+	open, err := sessions.Find(filter)
+	if err != nil && err != ErrNotFound {
+		return err
+	}
+
+	if transition == domain.StartTransition {
+		if open != nil {
+			open.Close(domain.AbandonedOutcome, at)
+			err = sessions.Store(*open)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = sessions.Create(domain.NewReadingSession(bookId, at))
+		return err
+	}
+
+	if open == nil {
+		if !startedAt.Valid {
+			return nil
+		}
+		session := domain.NewReadingSession(bookId, startedAt.Time)
+		session.Close(transition.SessionOutcome(), at)
+		_, err = sessions.Create(session)
 		return err
 	}
+	open.Close(transition.SessionOutcome(), at)
+	return sessions.Store(*open)
+}
 
-	switch book.ReadState {
-	case domain.NotReadValue:
-		book.SetStartState()
-	case domain.ReadingValue:
-		book.SetEndState()
-	case domain.ReadValue:
-		book.SetStartState()
-	default:
-		return errors.New("ChangeStatus: bad status")
+// ChangeRating sets or clears the rating of the book when one is given, and replaces its review when one is given.
+func (b *bookUseCase) ChangeRating(filter *Filter, rating *domain.NullFloat64, review *string) (*domain.Book, error) {
+	book, err := b.BookRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	if rating != nil {
+		err = domain.ValidateRating(*rating)
+		if err != nil {
+			return nil, err
+		}
+		book.Rating = *rating
+	}
+	if review != nil {
+		book.Review = *review
 	}
 	err = b.BookRepo.Store(*book, filter)
+	if err != nil {
+		return nil, err
+	}
+	return b.BookRepo.Find(filter)
+}
+
+// LogProgress records how far into the book the reader is, by page or percent, at the given time or now when at is nil.
+func (b *bookUseCase) LogProgress(filter *Filter, page *uint, percent *float64, at *time.Time) (*domain.Book, error) {
+	book, err := b.BookRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	recordedAt := time.Now()
+	if at != nil {
+		recordedAt = *at
+	}
+	entry, err := domain.NewProgressEntry(*book, page, percent, recordedAt)
+	if err != nil {
+		return nil, err
+	}
+	_, err = b.ProgressRepo.Create(*entry)
+	if err != nil {
+		return nil, err
+	}
+	return b.GetBook(filter)
+}
+
+func (b *bookUseCase) GetProgress(filter *Filter) (*domain.ProgressEntries, error) {
+	book, err := b.BookRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	progressFilter := NewFilter()
+	ByBookId(progressFilter, book.ID)
+	return b.ProgressRepo.FindAll(progressFilter)
+}
+
+// loadProgress fills in the current progress of each book that has any logged.
+func (b *bookUseCase) loadProgress(books domain.Books) error {
+	if len(books) == 0 {
+		return nil
+	}
+	bookIds := make([]uint64, 0, len(books))
+	for _, v := range books {
+		bookIds = append(bookIds, v.ID)
+	}
+	entries, err := b.ProgressRepo.FindAll(NewFilter().In(BookIdField, bookIds))
+	if err != nil {
+		return err
+	}
+	entriesByBook := map[uint64]domain.ProgressEntries{}
+	for _, v := range *entries {
+		entriesByBook[v.BookId] = append(entriesByBook[v.BookId], v)
+	}
+	for i := range books {
+		books[i].Progress = entriesByBook[books[i].ID].CurrentProgress()
+	}
+	return nil
+}
+
+// SetTags replaces the tags of the book. Every tag must belong to the account of the book.
+func (b *bookUseCase) SetTags(filter *Filter, tagIds []uint64) (*domain.Book, error) {
+	book, err := b.BookRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	uniqueIds := make([]uint64, 0, len(tagIds))
+	seen := map[uint64]bool{}
+	for _, id := range tagIds {
+		if !seen[id] {
+			seen[id] = true
+			uniqueIds = append(uniqueIds, id)
+		}
+	}
+	tagFilter := NewFilter().In(IdField, uniqueIds)
+	ByAccountId(tagFilter, book.AccountID)
+	tags, err := b.TagRepo.FindAll(tagFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(*tags) != len(uniqueIds) {
+		return nil, ErrNotFound
+	}
+
+	err = b.TagRepo.StoreBookTags(book.ID, uniqueIds)
+	if err != nil {
+		return nil, err
+	}
+	return b.BookRepo.Find(filter)
+}
+
+// FilterByTags narrows filter to the books carrying any of the named tags, or all of them when matchAll is set.
+func (b *bookUseCase) FilterByTags(filter *Filter, accountId string, tagNames []string, matchAll bool) error {
+	tagFilter := NewFilter().In(NameField, tagNames)
+	ByAccountId(tagFilter, accountId)
+	tags, err := b.TagRepo.FindAll(tagFilter)
+	if err != nil {
+		return err
+	}
+	if matchAll && len(*tags) < len(tagNames) {
+		// an unknown tag can never be matched
+		filter.In(IdField, []uint64{})
+		return nil
+	}
+
+	tagIds := make([]uint64, 0, len(*tags))
+	for _, v := range *tags {
+		tagIds = append(tagIds, v.ID)
+	}
+	bookIds, err := b.TagRepo.FindBookIds(tagIds, matchAll)
+	if err != nil {
+		return err
+	}
+	filter.In(IdField, bookIds)
+	return nil
+}
+
+// loadSeries fills book.Series with the series of the book and its neighbouring volumes.
+func (b *bookUseCase) loadSeries(book *domain.Book) error {
+	membership, err := b.SeriesRepo.FindMembers(NewFilter().Eq(BookIdField, book.ID))
+	if err != nil {
+		return err
+	}
+	if len(*membership) == 0 {
+		return nil
+	}
+	seriesId := (*membership)[0].SeriesId
+
+	seriesFilter := NewFilter()
+	ById(seriesFilter, seriesId)
+	series, err := b.SeriesRepo.Find(seriesFilter)
+	if err != nil {
+		return err
+	}
+	members, err := b.SeriesRepo.FindMembers(NewFilter().Eq(SeriesIdField, seriesId))
+	if err != nil {
+		return err
+	}
+
+	bookSeries := domain.BookSeries{ID: series.ID, Title: series.Title}
+	for i, v := range *members {
+		if v.BookId != book.ID {
+			continue
+		}
+		bookSeries.Position = i + 1
+		if i > 0 {
+			bookSeries.Prev, err = b.findOwnBook(book.AccountID, (*members)[i-1].BookId)
+			if err != nil {
+				return err
+			}
+		}
+		if i < len(*members)-1 {
+			bookSeries.Next, err = b.findOwnBook(book.AccountID, (*members)[i+1].BookId)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	book.Series = &bookSeries
+	return nil
+}
+
+func (b *bookUseCase) findOwnBook(accountId string, bookId uint64) (*domain.Book, error) {
+	filter := NewFilter()
+	ById(filter, bookId)
+	ByAccountId(filter, accountId)
+	return b.BookRepo.Find(filter)
+}
+
+// resolveAuthor replaces book.Author with the stored author of the same account.
+// An author given by id must exist; an author given only by name is created when missing.
+func (b *bookUseCase) resolveAuthor(book *domain.Book) error {
+	if book.Author == nil {
+		return nil
+	}
+	filter := NewFilter()
+	ByAccountId(filter, book.AccountID)
+	if book.Author.ID != 0 {
+		ById(filter, book.Author.ID)
+		author, err := b.AuthorRepo.Find(filter)
+		if err != nil {
+			return err
+		}
+		book.Author = author
+		return nil
+	}
+
+	ByName(filter, book.Author.Name)
+	author, err := b.AuthorRepo.Find(filter)
+	if err == ErrNotFound {
+		newAuthor := domain.Author{AccountID: book.AccountID, Name: book.Author.Name}
+		err = newAuthor.Validate()
+		if err != nil {
+			return err
+		}
+		author, err = b.AuthorRepo.Create(newAuthor)
+	}
 	if err != nil {
 		return err
 	}
+	book.Author = author
 	return nil
 }
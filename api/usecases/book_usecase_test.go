@@ -0,0 +1,50 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"bookshelf-web-api_gin_clean/api/usecases/usecasetest"
+)
+
+func TestDeleteBookRemovesCover(t *testing.T) {
+	bookRepo := &usecasetest.BookRepository{}
+	storage := &usecasetest.FileStorage{}
+	book := domain.NewBook()
+	book.AccountID = "account-a"
+	book.Title = "Dune"
+	book.Cover = "0123456789abcdef"
+	created, _ := bookRepo.Create(book)
+	for _, size := range domain.CoverSizes {
+		storage.Put(domain.CoverKey(created.ID, book.Cover, size), []byte("jpeg"))
+	}
+	// the cover of another book stays
+	otherKey := domain.CoverKey(created.ID+1, book.Cover, domain.FullCover)
+	storage.Put(otherKey, []byte("jpeg"))
+
+	u := usecases.NewBookUseCase(usecases.BookUseCaseDeps{BookRepo: bookRepo, Storage: storage})
+	filter := usecases.NewFilter()
+	usecases.ById(filter, created.ID)
+	usecases.ByAccountId(filter, "account-a")
+	err := u.DeleteBook(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bookRepo.Books) != 0 {
+		t.Errorf("got books %+v, want none", bookRepo.Books)
+	}
+	if len(storage.Files) != 1 || storage.Files[otherKey] == nil {
+		t.Errorf("got files %v, want only %s", storage.Files, otherKey)
+	}
+}
+
+func TestDeleteBookNotFound(t *testing.T) {
+	u := usecases.NewBookUseCase(usecases.BookUseCaseDeps{BookRepo: &usecasetest.BookRepository{}, Storage: &usecasetest.FileStorage{}})
+	filter := usecases.NewFilter()
+	usecases.ById(filter, 1)
+	if err := u.DeleteBook(filter); err != usecases.ErrNotFound {
+		t.Fatalf("got %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestResizeCover(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		max           int
+		wantW, wantH  int
+	}{
+		{"portrait", 800, 1200, 300, 200, 300},
+		{"landscape", 1200, 800, 300, 300, 200},
+		{"square", 1000, 1000, 300, 300, 300},
+		{"fits", 200, 300, 300, 200, 300},
+		{"smaller", 100, 50, 1200, 100, 50},
+		{"thin", 5000, 2, 300, 300, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+			got := resizeCover(img, tt.max).Bounds()
+			if got.Dx() != tt.wantW || got.Dy() != tt.wantH {
+				t.Errorf("got %dx%d, want %dx%d", got.Dx(), got.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestResizeCoverAveragesPixels(t *testing.T) {
+	// black and white columns shrink to grey
+	img := image.NewGray(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x += 2 {
+		for y := 0; y < 2; y++ {
+			img.SetGray(x+1, y, color.Gray{Y: 255})
+		}
+	}
+	got := resizeCover(img, 2)
+	r, g, b, _ := got.At(0, 0).RGBA()
+	if r>>8 != 127 || g>>8 != 127 || b>>8 != 127 {
+		t.Errorf("got %d,%d,%d, want 127,127,127", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestResizeCoverFlattensTransparency(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(10, 10, 12, 12))
+	got := resizeCover(img, 300)
+	if got.Bounds().Min != (image.Point{}) {
+		t.Errorf("got bounds %v, want them to start at 0,0", got.Bounds())
+	}
+	r, g, b, a := got.At(0, 0).RGBA()
+	if r>>8 != 255 || g>>8 != 255 || b>>8 != 255 || a>>8 != 255 {
+		t.Errorf("got %d,%d,%d,%d, want opaque white", r>>8, g>>8, b>>8, a>>8)
+	}
+}
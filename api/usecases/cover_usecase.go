@@ -0,0 +1,173 @@
+package usecases
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+)
+
+// maxCoverDimension bounds the width and height of uploads, so a small file cannot claim a huge canvas.
+const maxCoverDimension = 4000
+
+const coverQuality = 85
+
+type coverUseCase struct {
+	BookRepo BookRepository
+	Storage  FileStorage
+}
+type CoverUseCase interface {
+	UploadCover(filter *Filter, data []byte) (*domain.Book, error)
+	GetCover(filter *Filter, size domain.CoverSize) (io.ReadSeekCloser, string, error)
+	DeleteCover(filter *Filter) (*domain.Book, error)
+}
+
+func NewCoverUseCase(bookRepo BookRepository, storage FileStorage) CoverUseCase {
+	return &coverUseCase{BookRepo: bookRepo, Storage: storage}
+}
+
+// UploadCover stores the image in every cover size as JPEG and replaces the previous cover of the book.
+func (u *coverUseCase) UploadCover(filter *Filter, data []byte) (*domain.Book, error) {
+	book, err := u.BookRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrInvalidCover
+	}
+	if config.Width > maxCoverDimension || config.Height > maxCoverDimension {
+		return nil, domain.ErrCoverTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrInvalidCover
+	}
+
+	sum := sha1.Sum(data)
+	version := hex.EncodeToString(sum[:8])
+	for _, size := range domain.CoverSizes {
+		var buf bytes.Buffer
+		err = jpeg.Encode(&buf, resizeCover(img, size.MaxDimension()), &jpeg.Options{Quality: coverQuality})
+		if err != nil {
+			return nil, err
+		}
+		err = u.Storage.Put(domain.CoverKey(book.ID, version, size), buf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	previous := book.Cover
+	book.Cover = version
+	err = u.BookRepo.Store(*book, filter)
+	if err != nil {
+		return nil, err
+	}
+	if previous != "" && previous != version {
+		deleteCoverFiles(u.Storage, book.ID, previous)
+	}
+	return u.BookRepo.Find(filter)
+}
+
+// GetCover opens the cover image of the book in the given size and returns it with the cover version.
+func (u *coverUseCase) GetCover(filter *Filter, size domain.CoverSize) (io.ReadSeekCloser, string, error) {
+	if !size.IsValid() {
+		return nil, "", domain.ErrInvalidCoverSize
+	}
+	book, err := u.BookRepo.Find(filter)
+	if err != nil {
+		return nil, "", err
+	}
+	if book.Cover == "" {
+		return nil, "", ErrNotFound
+	}
+	file, err := u.Storage.Open(domain.CoverKey(book.ID, book.Cover, size))
+	if err != nil {
+		return nil, "", err
+	}
+	return file, book.Cover, nil
+}
+
+func (u *coverUseCase) DeleteCover(filter *Filter) (*domain.Book, error) {
+	book, err := u.BookRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	if book.Cover == "" {
+		return nil, ErrNotFound
+	}
+	previous := book.Cover
+	book.Cover = ""
+	err = u.BookRepo.Store(*book, filter)
+	if err != nil {
+		return nil, err
+	}
+	deleteCoverFiles(u.Storage, book.ID, previous)
+	return u.BookRepo.Find(filter)
+}
+
+// deleteCoverFiles removes the images of a replaced cover or a deleted book. Nothing points at them anymore,
+// so a failure only leaves unused files behind and is logged rather than returned.
+func deleteCoverFiles(storage FileStorage, bookId uint64, version string) {
+	for _, size := range domain.CoverSizes {
+		err := storage.Delete(domain.CoverKey(bookId, version, size))
+		if err != nil && err != ErrNotFound {
+			log.Println("deleteCoverFiles: ", err.Error())
+		}
+	}
+}
+
+// resizeCover scales the image down to fit within maxDimension on both sides, averaging the source pixels
+// each target pixel covers. Images that already fit are only flattened onto a white background,
+// since JPEG has no transparency.
+func resizeCover(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Over)
+
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	if width <= maxDimension && height <= maxDimension {
+		return src
+	}
+	dstWidth, dstHeight := maxDimension, height*maxDimension/width
+	if height > width {
+		dstWidth, dstHeight = width*maxDimension/height, maxDimension
+	}
+	if dstWidth < 1 {
+		dstWidth = 1
+	}
+	if dstHeight < 1 {
+		dstHeight = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0, y1 := y*height/dstHeight, (y+1)*height/dstHeight
+		for x := 0; x < dstWidth; x++ {
+			x0, x1 := x*width/dstWidth, (x+1)*width/dstWidth
+			var r, g, b, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					r += int(row[sx*4])
+					g += int(row[sx*4+1])
+					b += int(row[sx*4+2])
+					n++
+				}
+			}
+			i := y*dst.Stride + x*4
+			dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2], dst.Pix[i+3] = uint8(r/n), uint8(g/n), uint8(b/n), 255
+		}
+	}
+	return dst
+}
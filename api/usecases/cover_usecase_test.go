@@ -0,0 +1,71 @@
+package usecases_test
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"bookshelf-web-api_gin_clean/api/usecases/usecasetest"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUploadCover(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"too wide", encodePNG(t, 4001, 10), domain.ErrCoverTooLarge},
+		{"too tall", encodePNG(t, 10, 4001), domain.ErrCoverTooLarge},
+		{"not an image", []byte("%PDF-1.4"), domain.ErrInvalidCover},
+		{"empty", nil, domain.ErrInvalidCover},
+		{"largest allowed", encodePNG(t, 4000, 4000), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookRepo := &usecasetest.BookRepository{}
+			storage := &usecasetest.FileStorage{}
+			book := domain.NewBook()
+			book.Title = "Dune"
+			created, _ := bookRepo.Create(book)
+			filter := usecases.NewFilter()
+			usecases.ById(filter, created.ID)
+
+			u := usecases.NewCoverUseCase(bookRepo, storage)
+			got, err := u.UploadCover(filter, tt.data)
+			if err != tt.want {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if len(storage.Files) != 0 || bookRepo.Books[0].Cover != "" {
+					t.Errorf("a rejected cover left files %v and version %q", storage.Files, bookRepo.Books[0].Cover)
+				}
+				return
+			}
+			if len(storage.Files) != len(domain.CoverSizes) {
+				t.Errorf("got %d files, want one per size", len(storage.Files))
+			}
+			for _, size := range domain.CoverSizes {
+				config, err := jpeg.DecodeConfig(bytes.NewReader(storage.Files[domain.CoverKey(got.ID, got.Cover, size)]))
+				if err != nil {
+					t.Fatalf("%s cover: %v", size, err)
+				}
+				if config.Width != size.MaxDimension() || config.Height != size.MaxDimension() {
+					t.Errorf("%s cover is %dx%d, want %[4]dx%[4]d", size, config.Width, config.Height, size.MaxDimension())
+				}
+			}
+		})
+	}
+}
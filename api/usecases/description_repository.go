@@ -3,8 +3,9 @@ package usecases
 import "bookshelf-web-api_gin_clean/api/domain"
 
 type DescriptionRepository interface {
-	FindAll(filter map[string]interface{}, page uint64, perPage uint64) (*domain.Descriptions, error)
-	Find(filter map[string]interface{})  (*domain.Description, error)
+	FindAll(filter *Filter, paging Paging) (*domain.PaginateDescriptions, error)
+	Find(filter *Filter)  (*domain.Description, error)
 	Create(description domain.Description) (*domain.Description, error)
 	Delete(description domain.Description) error
+	Store(description domain.Description) error
 }
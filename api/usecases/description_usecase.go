@@ -7,43 +7,90 @@ type descriptionUseCase struct {
 	BookRepository  BookRepository
 }
 type DescriptionUseCase interface {
-	GetAllDescriptions(filter map[string]interface{}, page, perPage uint64) (*domain.Descriptions, error)
-	GetDescription(filter map[string]interface{}) (*domain.Description, error)
-	CreateDescription(createDescription domain.Description) (*domain.Description, error)
-	UpdateDescription(updateDescription domain.Description, filter map[string]interface{}) (error)
-	DeleteDescription(deleteDescription domain.Description) (error)
+	GetAllDescriptions(bookId uint64, accountId string, paging Paging) (*domain.PaginateDescriptions, error)
+	GetDescription(filter *Filter, accountId string) (*domain.Description, error)
+	CreateDescription(createDescription domain.Description, accountId string) (*domain.Description, error)
+	UpdateDescription(content string, filter *Filter, accountId string) (*domain.Description, error)
+	DeleteDescription(deleteDescription domain.Description, accountId string) (error)
 }
 
 func NewDescriptionUseCase(descRepo DescriptionRepository, bookRepo BookRepository) DescriptionUseCase {
 	return &descriptionUseCase{DescriptionRepo: descRepo, BookRepository: bookRepo}
 }
 
-func (b *descriptionUseCase) GetAllDescriptions(filter map[string]interface{}, page, perPage uint64) (*domain.Descriptions, error) {
-	descriptions, err := b.DescriptionRepo.FindAll(filter, page, perPage)
+func (b *descriptionUseCase) GetAllDescriptions(bookId uint64, accountId string, paging Paging) (*domain.PaginateDescriptions, error) {
+	err := b.checkBookOwner(bookId, accountId)
+	if err != nil {
+		return nil, err
+	}
+	filter := NewFilter()
+	ByBookId(filter, bookId)
+	descriptions, err := b.DescriptionRepo.FindAll(filter, paging)
 	if err != nil {
 		return nil, err
 	}
 	return descriptions, nil
 }
 
-func (b *descriptionUseCase) GetDescription(filter map[string]interface{}) (*domain.Description, error) {
+func (b *descriptionUseCase) GetDescription(filter *Filter, accountId string) (*domain.Description, error) {
 	description, err := b.DescriptionRepo.Find(filter)
 	if err != nil {
 		return nil, err
 	}
+	err = b.checkBookOwner(description.BookId, accountId)
+	if err != nil {
+		return nil, err
+	}
 	return description, nil
 }
-func (b *descriptionUseCase) CreateDescription(createDescription domain.Description) (*domain.Description, error) {
+func (b *descriptionUseCase) CreateDescription(createDescription domain.Description, accountId string) (*domain.Description, error) {
+	err := b.checkBookOwner(createDescription.BookId, accountId)
+	if err != nil {
+		return nil, err
+	}
+	err = createDescription.Validate()
+	if err != nil {
+		return nil, err
+	}
 	newDescription, err := b.DescriptionRepo.Create(createDescription)
 	if err != nil {
 		return nil, err
 	}
 	return newDescription, nil
 }
-func (b *descriptionUseCase) UpdateDescription(updateBook domain.Description, filter map[string]interface{}) (error) {
-	return nil
+func (b *descriptionUseCase) UpdateDescription(content string, filter *Filter, accountId string) (*domain.Description, error) {
+	description, err := b.GetDescription(filter, accountId)
+	if err != nil {
+		return nil, err
+	}
+	description.Edit(content)
+	err = description.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = b.DescriptionRepo.Store(*description)
+	if err != nil {
+		return nil, err
+	}
+	return b.DescriptionRepo.Find(filter)
+}
+
+func (b *descriptionUseCase) DeleteDescription(deleteDescription domain.Description, accountId string) (error) {
+	filter := NewFilter()
+	ById(filter, deleteDescription.ID)
+	description, err := b.GetDescription(filter, accountId)
+	if err != nil {
+		return err
+	}
+	return b.DescriptionRepo.Delete(*description)
 }
 
-func (b *descriptionUseCase) DeleteDescription(deleteDescription domain.Description) (error) {
-	return b.DescriptionRepo.Delete(deleteDescription)
+// checkBookOwner reports ErrNotFound unless the book exists and belongs to the account,
+// so that notes on other accounts' books look the same as missing ones.
+func (b *descriptionUseCase) checkBookOwner(bookId uint64, accountId string) error {
+	filter := NewFilter()
+	ById(filter, bookId)
+	ByAccountId(filter, accountId)
+	_, err := b.BookRepository.Find(filter)
+	return err
 }
@@ -0,0 +1,79 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"bookshelf-web-api_gin_clean/api/usecases/usecasetest"
+)
+
+const (
+	ownerAccount = "account-a"
+	otherAccount = "account-b"
+)
+
+// newDescriptionUseCase returns a use case over book 1 of ownerAccount carrying description 10.
+func newDescriptionUseCase() (usecases.DescriptionUseCase, *usecasetest.DescriptionRepository) {
+	book := domain.Book{AccountID: ownerAccount, Title: "Dune", ReadState: domain.NotReadValue}
+	book.ID = 1
+	description := domain.Description{BookId: 1, Content: "first note"}
+	description.ID = 10
+
+	bookRepo := &usecasetest.BookRepository{Books: domain.Books{book}}
+	descRepo := &usecasetest.DescriptionRepository{Descriptions: domain.Descriptions{description}}
+	return usecases.NewDescriptionUseCase(descRepo, bookRepo), descRepo
+}
+
+func descriptionFilter(id uint64) *usecases.Filter {
+	filter := usecases.NewFilter()
+	usecases.ById(filter, id)
+	return filter
+}
+
+func TestDescriptionUseCaseOwnership(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(u usecases.DescriptionUseCase, accountId string) error
+	}{
+		{"list", func(u usecases.DescriptionUseCase, accountId string) error {
+			_, err := u.GetAllDescriptions(1, accountId, usecases.Paging{})
+			return err
+		}},
+		{"get", func(u usecases.DescriptionUseCase, accountId string) error {
+			_, err := u.GetDescription(descriptionFilter(10), accountId)
+			return err
+		}},
+		{"create", func(u usecases.DescriptionUseCase, accountId string) error {
+			_, err := u.CreateDescription(domain.Description{BookId: 1, Content: "second note"}, accountId)
+			return err
+		}},
+		{"update", func(u usecases.DescriptionUseCase, accountId string) error {
+			_, err := u.UpdateDescription("edited", descriptionFilter(10), accountId)
+			return err
+		}},
+		{"delete", func(u usecases.DescriptionUseCase, accountId string) error {
+			description := domain.Description{}
+			description.ID = 10
+			return u.DeleteDescription(description, accountId)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" as owner", func(t *testing.T) {
+			u, _ := newDescriptionUseCase()
+			if err := tt.call(u, ownerAccount); err != nil {
+				t.Fatalf("got %v, want no error", err)
+			}
+		})
+		t.Run(tt.name+" as other account", func(t *testing.T) {
+			u, descRepo := newDescriptionUseCase()
+			if err := tt.call(u, otherAccount); err != usecases.ErrNotFound {
+				t.Fatalf("got %v, want %v", err, usecases.ErrNotFound)
+			}
+			if len(descRepo.Descriptions) != 1 || descRepo.Descriptions[0].Content != "first note" {
+				t.Fatalf("descriptions changed to %+v", descRepo.Descriptions)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package usecases
+
+import "errors"
+
+var (
+	ErrNotFound      = errors.New("record not found")
+	ErrAlreadyExists = errors.New("record already exists")
+)
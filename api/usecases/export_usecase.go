@@ -0,0 +1,89 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+// exportPageSize is how many books are read from the database at a time while exporting.
+const exportPageSize = 100
+
+type exportUseCase struct {
+	BookRepo        BookRepository
+	DescriptionRepo DescriptionRepository
+}
+type ExportUseCase interface {
+	ExportBooks(accountId string, write func(book domain.ExportedBook) error) error
+	ExportNotes(accountId string, write func(book domain.Book) error) error
+	GetBookNotes(filter *Filter) (*domain.Book, error)
+}
+
+func NewExportUseCase(bookRepo BookRepository, descRepo DescriptionRepository) ExportUseCase {
+	return &exportUseCase{BookRepo: bookRepo, DescriptionRepo: descRepo}
+}
+
+// ExportBooks passes every book of the account to write, oldest first, reading them a page at a time
+// so that the export can be streamed. It stops at the first error write returns.
+func (e *exportUseCase) ExportBooks(accountId string, write func(book domain.ExportedBook) error) error {
+	return e.eachBook(accountId, func(book domain.Book) error {
+		return write(domain.NewExportedBook(book, book.Descriptions))
+	})
+}
+
+// ExportNotes passes every book of the account to write like ExportBooks, with its tags and descriptions loaded.
+func (e *exportUseCase) ExportNotes(accountId string, write func(book domain.Book) error) error {
+	return e.eachBook(accountId, write)
+}
+
+// GetBookNotes returns the book with its tags and descriptions loaded.
+func (e *exportUseCase) GetBookNotes(filter *Filter) (*domain.Book, error) {
+	book, err := e.BookRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	descriptionFilter := NewFilter()
+	ByBookId(descriptionFilter, book.ID)
+	descriptions, err := e.DescriptionRepo.FindAll(descriptionFilter, Paging{})
+	if err != nil {
+		return nil, err
+	}
+	book.Descriptions = descriptions.Descriptions
+	return book, nil
+}
+
+func (e *exportUseCase) eachBook(accountId string, write func(book domain.Book) error) error {
+	var lastId uint64 = 0
+	for {
+		filter := NewFilter().Gt(IdField, lastId)
+		ByAccountId(filter, accountId)
+		books, err := e.BookRepo.FindAll(filter, Paging{Page: 1, PerPage: exportPageSize}, Sorts{{Field: IdField}})
+		if err != nil {
+			return err
+		}
+		if len(books.Books) == 0 {
+			return nil
+		}
+
+		bookIds := make([]uint64, 0, len(books.Books))
+		for _, v := range books.Books {
+			bookIds = append(bookIds, v.ID)
+		}
+		descriptions, err := e.DescriptionRepo.FindAll(NewFilter().In(BookIdField, bookIds), Paging{})
+		if err != nil {
+			return err
+		}
+		descriptionsByBook := map[uint64]domain.Descriptions{}
+		for _, v := range descriptions.Descriptions {
+			descriptionsByBook[v.BookId] = append(descriptionsByBook[v.BookId], v)
+		}
+
+		for _, v := range books.Books {
+			v.Descriptions = descriptionsByBook[v.ID]
+			err = write(v)
+			if err != nil {
+				return err
+			}
+			lastId = v.ID
+		}
+		if len(books.Books) < exportPageSize {
+			return nil
+		}
+	}
+}
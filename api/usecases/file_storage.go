@@ -0,0 +1,11 @@
+package usecases
+
+import "io"
+
+// FileStorage keeps files such as cover images under slash separated keys.
+// Open returns ErrNotFound for keys that hold no file.
+type FileStorage interface {
+	Put(key string, data []byte) error
+	Open(key string) (io.ReadSeekCloser, error)
+	Delete(key string) error
+}
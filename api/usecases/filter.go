@@ -1,22 +1,187 @@
 package usecases
 
-import "bookshelf-web-api_gin_clean/api/domain"
+import (
+	"strings"
 
-type Filter struct{}
+	"bookshelf-web-api_gin_clean/api/domain"
+)
 
-func NewFilter() map[string]interface{} {
-	return map[string]interface{}{}
+// Field is a column a Filter can match on.
+type Field string
+
+const (
+	IdField            Field = "id"
+	AccountIdField     Field = "account_id"
+	BookIdField        Field = "book_id"
+	AuthorIdField      Field = "author_id"
+	TagIdField         Field = "tag_id"
+	SeriesIdField      Field = "series_id"
+	PositionField      Field = "position"
+	TitleField         Field = "title"
+	NameField          Field = "name"
+	ContentField       Field = "content"
+	LocationKindField  Field = "location_kind"
+	StartPositionField Field = "start_position"
+	EndPositionField   Field = "end_position"
+	ReadStateField     Field = "read_state"
+	ReadCountField     Field = "read_count"
+	RatingField        Field = "rating"
+	OutcomeField       Field = "outcome"
+	StatusField        Field = "status"
+	StartAtField       Field = "start_at"
+	EndAtField         Field = "end_at"
+	StartedAtField     Field = "started_at"
+	YearField          Field = "year"
+	CreatedAtField     Field = "created_at"
+	UpdatedAtField     Field = "updated_at"
+)
+
+type Operator string
+
+const (
+	EqOperator      Operator = "="
+	NotEqOperator   Operator = "<>"
+	InOperator      Operator = "IN"
+	GtOperator      Operator = ">"
+	GteOperator     Operator = ">="
+	LtOperator      Operator = "<"
+	LteOperator     Operator = "<="
+	LikeOperator    Operator = "LIKE"
+	IsNullOperator  Operator = "IS NULL"
+	NotNullOperator Operator = "IS NOT NULL"
+)
+
+type Conjunction string
+
+const (
+	AndConjunction Conjunction = "AND"
+	OrConjunction  Conjunction = "OR"
+)
+
+type Condition struct {
+	Field    Field
+	Operator Operator
+	Value    interface{}
 }
 
-func ByAccountId(filter map[string]interface{}, id string) {
-	filter["account_id"] = id
+// Filter is a group of conditions and nested groups joined by a single conjunction.
+// Repositories hand it to DBConnection.Select, which translates it into a WHERE clause.
+type Filter struct {
+	Conjunction Conjunction
+	Conditions  []Condition
+	Groups      []*Filter
+}
+
+func NewFilter() *Filter {
+	return &Filter{Conjunction: AndConjunction}
+}
+
+func NewOrFilter() *Filter {
+	return &Filter{Conjunction: OrConjunction}
+}
+
+func (f *Filter) Where(field Field, operator Operator, value interface{}) *Filter {
+	f.Conditions = append(f.Conditions, Condition{Field: field, Operator: operator, Value: value})
+	return f
+}
+
+func (f *Filter) Eq(field Field, value interface{}) *Filter {
+	return f.Where(field, EqOperator, value)
+}
+
+func (f *Filter) NotEq(field Field, value interface{}) *Filter {
+	return f.Where(field, NotEqOperator, value)
+}
+
+// In matches any element of values, which must be a slice.
+func (f *Filter) In(field Field, values interface{}) *Filter {
+	return f.Where(field, InOperator, values)
+}
+
+func (f *Filter) Gt(field Field, value interface{}) *Filter {
+	return f.Where(field, GtOperator, value)
+}
+
+func (f *Filter) Gte(field Field, value interface{}) *Filter {
+	return f.Where(field, GteOperator, value)
+}
+
+func (f *Filter) Lt(field Field, value interface{}) *Filter {
+	return f.Where(field, LtOperator, value)
+}
+
+func (f *Filter) Lte(field Field, value interface{}) *Filter {
+	return f.Where(field, LteOperator, value)
+}
+
+// Between matches the inclusive range from..to.
+func (f *Filter) Between(field Field, from, to interface{}) *Filter {
+	return f.Gte(field, from).Lte(field, to)
+}
+
+// Like matches a SQL LIKE pattern; use Contains to match a plain substring.
+func (f *Filter) Like(field Field, pattern string) *Filter {
+	return f.Where(field, LikeOperator, pattern)
+}
+
+func (f *Filter) IsNull(field Field) *Filter {
+	return f.Where(field, IsNullOperator, nil)
+}
+
+func (f *Filter) NotNull(field Field) *Filter {
+	return f.Where(field, NotNullOperator, nil)
+}
+
+// Group nests groups into f, each keeping its own conjunction.
+func (f *Filter) Group(groups ...*Filter) *Filter {
+	f.Groups = append(f.Groups, groups...)
+	return f
+}
+
+// And nests a group matching all of groups.
+func (f *Filter) And(groups ...*Filter) *Filter {
+	return f.Group(&Filter{Conjunction: AndConjunction, Groups: groups})
+}
+
+// Or nests a group matching any of groups.
+func (f *Filter) Or(groups ...*Filter) *Filter {
+	return f.Group(&Filter{Conjunction: OrConjunction, Groups: groups})
+}
+
+func (f *Filter) IsEmpty() bool {
+	if len(f.Conditions) > 0 {
+		return false
+	}
+	for _, g := range f.Groups {
+		if !g.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Contains builds a LIKE pattern matching s anywhere, with wildcards in s escaped.
+func Contains(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+func ByAccountId(filter *Filter, id string) {
+	filter.Eq(AccountIdField, id)
+}
+func ById(filter *Filter, id uint64) {
+	filter.Eq(IdField, id)
+}
+func ByBookId(filter *Filter, id uint64) {
+	filter.Eq(BookIdField, id)
 }
-func ById(filter map[string]interface{}, id uint64) {
-	filter["id"] = id
+func ByStatus(filter *Filter, status domain.ReadState) {
+	filter.Eq(ReadStateField, status)
 }
-func ByBookId(filter map[string]interface{}, id uint64) {
-	filter["book_id"] = id
+func ByName(filter *Filter, name string) {
+	filter.Eq(NameField, name)
 }
-func ByStatus(filter map[string]interface{}, status domain.ReadState) {
-	filter["read_state"] = status
+func ByOutcome(filter *Filter, outcome domain.SessionOutcome) {
+	filter.Eq(OutcomeField, outcome)
 }
@@ -0,0 +1,11 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type GoalRepository interface {
+	FindAll(filter *Filter) (*domain.Goals, error)
+	Find(filter *Filter) (*domain.Goal, error)
+	Create(goal domain.Goal) (*domain.Goal, error)
+	Delete(goal domain.Goal) error
+	Store(goal domain.Goal) error
+}
@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+)
+
+type goalUseCase struct {
+	GoalRepo  GoalRepository
+	StatsRepo StatsRepository
+}
+type GoalUseCase interface {
+	GetAllGoals(accountId string) (*domain.GoalProgresses, error)
+	GetGoal(accountId string, year int) (*domain.GoalProgress, error)
+	SetGoal(goal domain.Goal) (*domain.GoalProgress, error)
+	DeleteGoal(accountId string, year int) error
+}
+
+func NewGoalUseCase(goalRepo GoalRepository, statsRepo StatsRepository) GoalUseCase {
+	return &goalUseCase{GoalRepo: goalRepo, StatsRepo: statsRepo}
+}
+
+func (g *goalUseCase) GetAllGoals(accountId string) (*domain.GoalProgresses, error) {
+	filter := NewFilter()
+	ByAccountId(filter, accountId)
+	goals, err := g.GoalRepo.FindAll(filter)
+	if err != nil {
+		return nil, err
+	}
+	progresses := make(domain.GoalProgresses, 0, len(*goals))
+	for _, v := range *goals {
+		progress, err := g.progress(v)
+		if err != nil {
+			return nil, err
+		}
+		progresses = append(progresses, *progress)
+	}
+	return &progresses, nil
+}
+
+func (g *goalUseCase) GetGoal(accountId string, year int) (*domain.GoalProgress, error) {
+	goal, err := g.GoalRepo.Find(goalFilter(accountId, year))
+	if err != nil {
+		return nil, err
+	}
+	return g.progress(*goal)
+}
+
+// SetGoal sets the target for the year of goal, replacing any target set before.
+func (g *goalUseCase) SetGoal(goal domain.Goal) (*domain.GoalProgress, error) {
+	err := goal.Validate()
+	if err != nil {
+		return nil, err
+	}
+	filter := goalFilter(goal.AccountID, goal.Year)
+	current, err := g.GoalRepo.Find(filter)
+	if err == ErrNotFound {
+		_, err = g.GoalRepo.Create(goal)
+		if err != nil {
+			return nil, err
+		}
+		return g.GetGoal(goal.AccountID, goal.Year)
+	}
+	if err != nil {
+		return nil, err
+	}
+	current.Target = goal.Target
+	err = g.GoalRepo.Store(*current)
+	if err != nil {
+		return nil, err
+	}
+	return g.GetGoal(goal.AccountID, goal.Year)
+}
+
+func (g *goalUseCase) DeleteGoal(accountId string, year int) error {
+	goal, err := g.GoalRepo.Find(goalFilter(accountId, year))
+	if err != nil {
+		return err
+	}
+	return g.GoalRepo.Delete(*goal)
+}
+
+// progress counts the books of the account finished within the year of goal.
+func (g *goalUseCase) progress(goal domain.Goal) (*domain.GoalProgress, error) {
+	start, end := domain.YearRange(goal.Year)
+	filter := NewFilter().Gte(EndAtField, start).Lt(EndAtField, end)
+	ByAccountId(filter, goal.AccountID)
+	ByStatus(filter, domain.ReadValue)
+	finished, err := g.StatsRepo.CountBooks(filter)
+	if err != nil {
+		return nil, err
+	}
+	progress := domain.NewGoalProgress(goal, finished, time.Now())
+	return &progress, nil
+}
+
+func goalFilter(accountId string, year int) *Filter {
+	filter := NewFilter().Eq(YearField, year)
+	ByAccountId(filter, accountId)
+	return filter
+}
@@ -0,0 +1,11 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type HighlightRepository interface {
+	FindAll(filter *Filter) (*domain.Highlights, error)
+	Find(filter *Filter) (*domain.Highlight, error)
+	Create(highlight domain.Highlight) (*domain.Highlight, error)
+	Delete(highlight domain.Highlight) error
+	Store(highlight domain.Highlight) error
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type highlightUseCase struct {
+	HighlightRepo HighlightRepository
+	BookRepo      BookRepository
+}
+type HighlightUseCase interface {
+	GetAllHighlights(bookId uint64, accountId string) (*domain.Highlights, error)
+	GetHighlight(filter *Filter, accountId string) (*domain.Highlight, error)
+	CreateHighlight(createHighlight domain.Highlight, accountId string) (*domain.Highlight, error)
+	UpdateHighlight(updateHighlight domain.Highlight, filter *Filter, accountId string) (*domain.Highlight, error)
+	DeleteHighlight(filter *Filter, accountId string) error
+}
+
+func NewHighlightUseCase(highlightRepo HighlightRepository, bookRepo BookRepository) HighlightUseCase {
+	return &highlightUseCase{HighlightRepo: highlightRepo, BookRepo: bookRepo}
+}
+
+func (h *highlightUseCase) GetAllHighlights(bookId uint64, accountId string) (*domain.Highlights, error) {
+	err := h.checkBookOwner(bookId, accountId)
+	if err != nil {
+		return nil, err
+	}
+	filter := NewFilter()
+	ByBookId(filter, bookId)
+	return h.HighlightRepo.FindAll(filter)
+}
+
+func (h *highlightUseCase) GetHighlight(filter *Filter, accountId string) (*domain.Highlight, error) {
+	highlight, err := h.HighlightRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	err = h.checkBookOwner(highlight.BookId, accountId)
+	if err != nil {
+		return nil, err
+	}
+	return highlight, nil
+}
+
+func (h *highlightUseCase) CreateHighlight(createHighlight domain.Highlight, accountId string) (*domain.Highlight, error) {
+	err := h.checkBookOwner(createHighlight.BookId, accountId)
+	if err != nil {
+		return nil, err
+	}
+	err = createHighlight.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return h.HighlightRepo.Create(createHighlight)
+}
+
+func (h *highlightUseCase) UpdateHighlight(updateHighlight domain.Highlight, filter *Filter, accountId string) (*domain.Highlight, error) {
+	highlight, err := h.GetHighlight(filter, accountId)
+	if err != nil {
+		return nil, err
+	}
+	updateHighlight.ID = highlight.ID
+	updateHighlight.BookId = highlight.BookId
+	updateHighlight.CreatedAt = highlight.CreatedAt
+	err = updateHighlight.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = h.HighlightRepo.Store(updateHighlight)
+	if err != nil {
+		return nil, err
+	}
+	return h.HighlightRepo.Find(filter)
+}
+
+func (h *highlightUseCase) DeleteHighlight(filter *Filter, accountId string) error {
+	highlight, err := h.GetHighlight(filter, accountId)
+	if err != nil {
+		return err
+	}
+	return h.HighlightRepo.Delete(*highlight)
+}
+
+func (h *highlightUseCase) checkBookOwner(bookId uint64, accountId string) error {
+	filter := NewFilter()
+	ById(filter, bookId)
+	ByAccountId(filter, accountId)
+	_, err := h.BookRepo.Find(filter)
+	return err
+}
@@ -0,0 +1,10 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type ImportJobRepository interface {
+	FindAll(filter *Filter) (*domain.ImportJobs, error)
+	Find(filter *Filter) (*domain.ImportJob, error)
+	Create(job domain.ImportJob) (*domain.ImportJob, error)
+	Store(job domain.ImportJob) error
+}
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"errors"
+	"sync"
+)
+
+const (
+	// importWorkers is how many background imports run at once.
+	importWorkers = 2
+	// maxQueuedImports is how many background imports may wait for a worker.
+	maxQueuedImports = 50
+)
+
+var ErrImportQueueFull = errors.New("too many imports waiting to run")
+
+// importRunner runs background imports on a fixed number of workers, oldest first.
+// It also keeps two imports into the same account, in the background or not, from running at once,
+// as each one skips the books it saw on the shelf when it started.
+type importRunner struct {
+	mu      sync.Mutex
+	idle    *sync.Cond
+	workers int
+	queue   []queuedImport
+	busy    map[string]bool
+}
+
+type queuedImport struct {
+	accountId string
+	run       func()
+}
+
+func newImportRunner(workers int) *importRunner {
+	r := &importRunner{workers: workers, busy: map[string]bool{}}
+	r.idle = sync.NewCond(&r.mu)
+	return r
+}
+
+// Enqueue queues run as an import into the account, to be run on a worker once no other import into it runs.
+func (r *importRunner) Enqueue(accountId string, run func()) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.queue) >= maxQueuedImports {
+		return ErrImportQueueFull
+	}
+	r.queue = append(r.queue, queuedImport{accountId: accountId, run: run})
+	r.dispatch()
+	return nil
+}
+
+// Lock waits until no import into the account runs and keeps queued ones from starting until Unlock.
+func (r *importRunner) Lock(accountId string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for r.busy[accountId] {
+		r.idle.Wait()
+	}
+	r.busy[accountId] = true
+}
+
+func (r *importRunner) Unlock(accountId string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.release(accountId)
+}
+
+// release frees the account and starts what waited on it. r.mu must be held.
+func (r *importRunner) release(accountId string) {
+	delete(r.busy, accountId)
+	r.idle.Broadcast()
+	r.dispatch()
+}
+
+// dispatch starts the oldest queued imports into accounts that are not busy, while workers are free.
+// r.mu must be held.
+func (r *importRunner) dispatch() {
+	for i := 0; i < len(r.queue) && r.workers > 0; {
+		next := r.queue[i]
+		if r.busy[next.accountId] {
+			i++
+			continue
+		}
+		r.queue = append(r.queue[:i], r.queue[i+1:]...)
+		r.busy[next.accountId] = true
+		r.workers--
+		go r.work(next)
+	}
+}
+
+func (r *importRunner) work(next queuedImport) {
+	defer func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.workers++
+		r.release(next.accountId)
+	}()
+	next.run()
+}
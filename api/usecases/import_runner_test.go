@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestImportRunner(t *testing.T) {
+	r := newImportRunner(2)
+	var (
+		mu             sync.Mutex
+		running        = map[string]int{}
+		total, maxSeen int
+		order          []int
+		done           sync.WaitGroup
+	)
+	accounts := []string{"a", "a", "b", "a", "c", "b"}
+	for n, accountId := range accounts {
+		n, accountId := n, accountId
+		done.Add(1)
+		err := r.Enqueue(accountId, func() {
+			defer done.Done()
+			mu.Lock()
+			running[accountId]++
+			total++
+			if running[accountId] > 1 {
+				t.Errorf("account %s runs %d imports at once", accountId, running[accountId])
+			}
+			if total > maxSeen {
+				maxSeen = total
+			}
+			order = append(order, n)
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running[accountId]--
+			total--
+			mu.Unlock()
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	done.Wait()
+
+	if maxSeen > 2 {
+		t.Errorf("%d imports ran at once on 2 workers", maxSeen)
+	}
+	if len(order) != len(accounts) {
+		t.Fatalf("ran %d imports, want %d", len(order), len(accounts))
+	}
+	// the imports into an account run in the order they were queued
+	last := map[string]int{}
+	for _, n := range order {
+		if prev, ok := last[accounts[n]]; ok && prev > n {
+			t.Errorf("import %d into %s ran after import %d", prev, accounts[n], n)
+		}
+		last[accounts[n]] = n
+	}
+}
+
+func TestImportRunnerLock(t *testing.T) {
+	r := newImportRunner(1)
+	r.Lock("a")
+
+	ran := make(chan string, 2)
+	r.Enqueue("a", func() { ran <- "a" })
+	r.Enqueue("b", func() { ran <- "b" })
+	if got := <-ran; got != "b" {
+		t.Fatalf("import into %s ran while its account was locked", got)
+	}
+
+	r.Unlock("a")
+	if got := <-ran; got != "a" {
+		t.Fatalf("got import into %s, want a", got)
+	}
+}
+
+func TestImportRunnerQueueFull(t *testing.T) {
+	r := newImportRunner(0)
+	for i := 0; i < maxQueuedImports; i++ {
+		err := r.Enqueue("a", func() {})
+		if err != nil {
+			t.Fatalf("import %d: %v", i, err)
+		}
+	}
+	if err := r.Enqueue("b", func() {}); err != ErrImportQueueFull {
+		t.Fatalf("got %v, want ErrImportQueueFull", err)
+	}
+}
@@ -0,0 +1,566 @@
+package usecases
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+)
+
+// ImportRecord is one row of an imported library, as the text found in each column.
+// Status may be left empty to infer it from the dates given. A read or abandoned row needs at least
+// its end date, which also stands for a missing start date, and a reading row its start date.
+// Notes and each of Descriptions become a description of the book, and Tags are put on it,
+// creating the tags the account does not have yet. There is no cover: the cover_url of an export
+// points at an image of the exported book, which an import cannot bring back.
+type ImportRecord struct {
+	Line          int
+	Title         string
+	Author        string
+	Status        string
+	ReadCount     string
+	StartAt       string
+	EndAt         string
+	Rating        string
+	Review        string
+	Notes         string
+	ISBN          string
+	ISBN13        string
+	TotalPages    string
+	Publisher     string
+	PublishedDate string
+	Tags          []string
+	Descriptions  domain.ExportedDescriptions
+}
+
+// NewImportRecord formats an exported book as the record it is imported back from.
+func NewImportRecord(line int, book domain.ExportedBook) ImportRecord {
+	r := ImportRecord{
+		Line:          line,
+		Title:         book.Title,
+		Author:        book.Author,
+		Status:        book.Status,
+		Review:        book.Review,
+		ISBN:          book.ISBN,
+		ISBN13:        book.ISBN13,
+		Publisher:     book.Publisher,
+		PublishedDate: book.PublishedDate,
+		Tags:          book.Tags,
+		Descriptions:  book.Descriptions,
+	}
+	if book.ReadCount > 0 {
+		r.ReadCount = strconv.FormatUint(uint64(book.ReadCount), 10)
+	}
+	if book.StartAt.Valid {
+		r.StartAt = book.StartAt.Time.Format(time.RFC3339)
+	}
+	if book.EndAt.Valid {
+		r.EndAt = book.EndAt.Time.Format(time.RFC3339)
+	}
+	if book.Rating.Valid {
+		r.Rating = strconv.FormatFloat(book.Rating.Float64, 'f', -1, 64)
+	}
+	if book.TotalPages > 0 {
+		r.TotalPages = strconv.FormatUint(uint64(book.TotalPages), 10)
+	}
+	return r
+}
+
+type ClippingKind string
+
+const (
+	HighlightClipping ClippingKind = "highlight"
+	NoteClipping      ClippingKind = "note"
+)
+
+// Clipping is an entry of a Kindle "My Clippings.txt" file. Page and Location are kept as written,
+// such as "170-172"; AddedAt is zero when the timestamp could not be read.
+type Clipping struct {
+	Line     int
+	Title    string
+	Author   string
+	Kind     ClippingKind
+	Page     string
+	Location string
+	AddedAt  time.Time
+	Text     string
+}
+
+// Content is the description a clipping is stored as: its text followed by where it was taken from.
+func (c Clipping) Content() string {
+	where := make([]string, 0, 2)
+	if c.Page != "" {
+		where = append(where, "page "+c.Page)
+	}
+	if c.Location != "" {
+		where = append(where, "location "+c.Location)
+	}
+	if len(where) == 0 {
+		return c.Text
+	}
+	return fmt.Sprintf("%s\n\n(Kindle %s, %s)", c.Text, c.Kind, strings.Join(where, ", "))
+}
+
+// SourceKey identifies the clipping across imports of the same or a later clippings file.
+func (c Clipping) SourceKey() string {
+	sum := sha1.Sum([]byte(strings.Join([]string{"kindle", string(c.Kind), c.Location, c.Page, c.Text}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// importDateLayouts are the date formats accepted in imported rows.
+var importDateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+}
+
+// jobProgressInterval is how many rows a background import processes between saving its progress.
+const jobProgressInterval = 50
+
+var ErrImportInterrupted = errors.New("import interrupted by a server restart")
+
+type importUseCase struct {
+	BookUseCase        BookUseCase
+	DescriptionUseCase DescriptionUseCase
+	TagUseCase         TagUseCase
+	BookRepo           BookRepository
+	JobRepo            ImportJobRepository
+	Runner             *importRunner
+}
+type ImportUseCase interface {
+	ImportBooks(accountId string, records []ImportRecord) (*domain.ImportReport, error)
+	ImportClippings(accountId string, clippings []Clipping) (*domain.ImportReport, error)
+	StartImport(accountId string, source string, records []ImportRecord) (*domain.ImportJob, error)
+	GetImportJob(filter *Filter) (*domain.ImportJob, error)
+	FailInterruptedJobs() error
+}
+
+func NewImportUseCase(bookUseCase BookUseCase, descriptionUseCase DescriptionUseCase, tagUseCase TagUseCase, bookRepo BookRepository, jobRepo ImportJobRepository) ImportUseCase {
+	return &importUseCase{BookUseCase: bookUseCase, DescriptionUseCase: descriptionUseCase, TagUseCase: tagUseCase, BookRepo: bookRepo, JobRepo: jobRepo, Runner: newImportRunner(importWorkers)}
+}
+
+// ImportBooks creates a book for each record, with its notes and descriptions and its tags.
+// Records matching a book already on the shelf, or an earlier record, by title and author or by ISBN are skipped.
+// A record that cannot be imported is reported as failed without stopping the import.
+func (i *importUseCase) ImportBooks(accountId string, records []ImportRecord) (*domain.ImportReport, error) {
+	i.Runner.Lock(accountId)
+	defer i.Runner.Unlock(accountId)
+	report := domain.NewImportReport()
+	err := i.importRecords(accountId, records, &report, func() {})
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ImportClippings stores each clipping as a description of its book, matched by title and author
+// or created when missing. Clippings already imported are skipped.
+func (i *importUseCase) ImportClippings(accountId string, clippings []Clipping) (*domain.ImportReport, error) {
+	i.Runner.Lock(accountId)
+	defer i.Runner.Unlock(accountId)
+	filter := NewFilter()
+	ByAccountId(filter, accountId)
+	existing, err := i.BookRepo.FindAll(filter, Paging{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	books := map[string]uint64{}
+	for _, v := range existing.Books {
+		books[bookKeys(v)[0]] = v.ID
+	}
+	sourceKeys := map[uint64]map[string]bool{}
+
+	report := domain.NewImportReport()
+	for _, clipping := range clippings {
+		row := domain.ImportRow{Line: clipping.Line, Title: clipping.Title}
+		bookId, err := i.clippingBook(accountId, clipping, books)
+		if err != nil {
+			row.Result = domain.FailedImport
+			row.Error = err.Error()
+			report.Add(row)
+			continue
+		}
+		row.BookID = bookId
+
+		if _, ok := sourceKeys[bookId]; !ok {
+			sourceKeys[bookId], err = i.descriptionSourceKeys(bookId, accountId)
+			if err != nil {
+				return nil, err
+			}
+		}
+		key := clipping.SourceKey()
+		if sourceKeys[bookId][key] {
+			row.Result = domain.SkippedImport
+			row.Error = "clipping already imported"
+			report.Add(row)
+			continue
+		}
+
+		description := domain.Description{BookId: bookId, Content: clipping.Content(), SourceKey: key}
+		description.CreatedAt = clipping.AddedAt
+		_, err = i.DescriptionUseCase.CreateDescription(description, accountId)
+		if err != nil {
+			row.Result = domain.FailedImport
+			row.Error = err.Error()
+			report.Add(row)
+			continue
+		}
+		sourceKeys[bookId][key] = true
+		row.Result = domain.CreatedImport
+		report.Add(row)
+	}
+	return &report, nil
+}
+
+// clippingBook returns the book a clipping was taken from, creating it unread when it is not on the shelf yet.
+func (i *importUseCase) clippingBook(accountId string, clipping Clipping, books map[string]uint64) (uint64, error) {
+	book := domain.NewBook()
+	book.AccountID = accountId
+	book.Title = strings.TrimSpace(clipping.Title)
+	book.ReadState = domain.NotReadValue
+	if name := strings.TrimSpace(clipping.Author); name != "" {
+		book.Author = &domain.Author{Name: name}
+	}
+	key := bookKeys(book)[0]
+	if id, ok := books[key]; ok {
+		return id, nil
+	}
+	newBook, err := i.BookUseCase.CreateBook(book)
+	if err != nil {
+		return 0, err
+	}
+	books[key] = newBook.ID
+	return newBook.ID, nil
+}
+
+func (i *importUseCase) descriptionSourceKeys(bookId uint64, accountId string) (map[string]bool, error) {
+	descriptions, err := i.DescriptionUseCase.GetAllDescriptions(bookId, accountId, Paging{})
+	if err != nil {
+		return nil, err
+	}
+	keys := map[string]bool{}
+	for _, v := range descriptions.Descriptions {
+		if v.SourceKey != "" {
+			keys[v.SourceKey] = true
+		}
+	}
+	return keys, nil
+}
+
+// StartImport queues the records as an import job, run in the background after the earlier imports into the account.
+// The job can be followed with GetImportJob. It fails with ErrImportQueueFull when too many jobs wait to run.
+func (i *importUseCase) StartImport(accountId string, source string, records []ImportRecord) (*domain.ImportJob, error) {
+	job, err := i.JobRepo.Create(domain.NewImportJob(accountId, source, len(records)))
+	if err != nil {
+		return nil, err
+	}
+	queued := *job
+	err = i.Runner.Enqueue(accountId, func() {
+		i.runImport(queued, records)
+	})
+	if err != nil {
+		job.Finish(err)
+		i.storeJob(*job)
+		return nil, err
+	}
+	return job, nil
+}
+
+func (i *importUseCase) GetImportJob(filter *Filter) (*domain.ImportJob, error) {
+	return i.JobRepo.Find(filter)
+}
+
+// FailInterruptedJobs fails the jobs left queued or running by an earlier run of the server,
+// which nothing will pick up again. It is meant to run once at startup, before any import is started.
+func (i *importUseCase) FailInterruptedJobs() error {
+	filter := NewFilter().In(StatusField, []string{string(domain.QueuedJob), string(domain.RunningJob)})
+	jobs, err := i.JobRepo.FindAll(filter)
+	if err != nil {
+		return err
+	}
+	for _, job := range *jobs {
+		job.Finish(ErrImportInterrupted)
+		err = i.JobRepo.Store(job)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *importUseCase) runImport(job domain.ImportJob, records []ImportRecord) {
+	job.Status = domain.RunningJob
+	i.storeJob(job)
+
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("import stopped: %v", r)
+			}
+		}()
+		err = i.importRecords(job.AccountID, records, &job.Report, func() {
+			job.Processed++
+			if job.Processed%jobProgressInterval == 0 {
+				i.storeJob(job)
+			}
+		})
+	}()
+	job.Finish(err)
+	i.storeJob(job)
+}
+
+// storeJob saves the progress of a background job, which has no caller to report a failure to.
+func (i *importUseCase) storeJob(job domain.ImportJob) {
+	err := i.JobRepo.Store(job)
+	if err != nil {
+		log.Println("import job ", job.ID, ": ", err.Error())
+	}
+}
+
+// importRecords adds a row to report for each record and calls done after each one.
+func (i *importUseCase) importRecords(accountId string, records []ImportRecord, report *domain.ImportReport, done func()) error {
+	filter := NewFilter()
+	ByAccountId(filter, accountId)
+	existing, err := i.BookRepo.FindAll(filter, Paging{}, nil)
+	if err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for _, v := range existing.Books {
+		for _, key := range bookKeys(v) {
+			seen[key] = true
+		}
+	}
+	tagFilter := NewFilter()
+	ByAccountId(tagFilter, accountId)
+	existingTags, err := i.TagUseCase.GetAllTags(tagFilter)
+	if err != nil {
+		return err
+	}
+	tags := map[string]uint64{}
+	for _, v := range *existingTags {
+		tags[strings.ToLower(v.Name)] = v.ID
+	}
+
+	for _, record := range records {
+		row := domain.ImportRow{Line: record.Line, Title: record.Title}
+		book, err := record.toBook(accountId)
+		if err != nil {
+			row.Result = domain.FailedImport
+			row.Error = err.Error()
+			report.Add(row)
+			done()
+			continue
+		}
+		if isSeen(seen, *book) {
+			row.Result = domain.SkippedImport
+			row.Error = "book already exists"
+			report.Add(row)
+			done()
+			continue
+		}
+
+		newBook, err := i.BookUseCase.CreateBook(*book)
+		if err != nil {
+			row.Result = domain.FailedImport
+			row.Error = err.Error()
+			report.Add(row)
+			done()
+			continue
+		}
+		for _, key := range bookKeys(*book) {
+			seen[key] = true
+		}
+		row.Result = domain.CreatedImport
+		row.BookID = newBook.ID
+
+		for _, description := range record.descriptions(newBook.ID) {
+			_, err = i.DescriptionUseCase.CreateDescription(description, accountId)
+			if err != nil {
+				row.Error = fmt.Sprintf("notes not saved: %s", err)
+				break
+			}
+		}
+		if row.Error == "" {
+			err = i.tagBook(accountId, newBook.ID, record.Tags, tags)
+			if err != nil {
+				row.Error = fmt.Sprintf("tags not saved: %s", err)
+			}
+		}
+		report.Add(row)
+		done()
+	}
+	return nil
+}
+
+// tagBook puts the named tags on the book. tags maps the lower-cased names of the account's tags to their ids,
+// and gains the tags created for names it does not hold.
+func (i *importUseCase) tagBook(accountId string, bookId uint64, names []string, tags map[string]uint64) error {
+	tagIds := make([]uint64, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		key := strings.ToLower(name)
+		if _, ok := tags[key]; !ok {
+			tag, err := i.TagUseCase.CreateTag(domain.Tag{AccountID: accountId, Name: name})
+			if err != nil {
+				return err
+			}
+			tags[key] = tag.ID
+		}
+		tagIds = append(tagIds, tags[key])
+	}
+	if len(tagIds) == 0 {
+		return nil
+	}
+	filter := NewFilter()
+	ById(filter, bookId)
+	ByAccountId(filter, accountId)
+	_, err := i.BookUseCase.SetTags(filter, tagIds)
+	return err
+}
+
+// descriptions returns the notes and descriptions of the record to create on the book,
+// keeping the times exported descriptions were written and edited and the sources they were imported from.
+func (r ImportRecord) descriptions(bookId uint64) domain.Descriptions {
+	descriptions := make(domain.Descriptions, 0, len(r.Descriptions)+1)
+	if notes := strings.TrimSpace(r.Notes); notes != "" {
+		descriptions = append(descriptions, domain.Description{BookId: bookId, Content: notes})
+	}
+	for _, v := range r.Descriptions {
+		content := strings.TrimSpace(v.Content)
+		if content == "" {
+			continue
+		}
+		description := domain.Description{BookId: bookId, Content: content, EditedAt: v.EditedAt, SourceKey: v.SourceKey}
+		description.CreatedAt = v.CreatedAt
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
+
+func (r ImportRecord) toBook(accountId string) (*domain.Book, error) {
+	book := domain.NewBook()
+	book.AccountID = accountId
+	book.Title = strings.TrimSpace(r.Title)
+	book.Review = strings.TrimSpace(r.Review)
+	book.ISBN = domain.NormalizeISBN(r.ISBN)
+	book.ISBN13 = domain.NormalizeISBN(r.ISBN13)
+	book.Publisher = strings.TrimSpace(r.Publisher)
+	book.PublishedDate = strings.TrimSpace(r.PublishedDate)
+	if name := strings.TrimSpace(r.Author); name != "" {
+		book.Author = &domain.Author{Name: name}
+	}
+
+	var err error
+	book.StartAt, err = parseImportDate(r.StartAt)
+	if err != nil {
+		return nil, err
+	}
+	book.EndAt, err = parseImportDate(r.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	status := strings.ToLower(strings.TrimSpace(r.Status))
+	switch {
+	case status != "":
+		book.ReadState, err = domain.ParseReadState(strings.Replace(status, " ", "_", -1))
+		if err != nil {
+			return nil, err
+		}
+	case book.EndAt.Valid:
+		book.ReadState = domain.ReadValue
+	case book.StartAt.Valid:
+		book.ReadState = domain.ReadingValue
+	default:
+		book.ReadState = domain.NotReadValue
+	}
+	switch book.ReadState {
+	case domain.ReadValue, domain.AbandonedValue:
+		if !book.EndAt.Valid {
+			return nil, domain.ErrMissingEndDate
+		}
+	case domain.ReadingValue:
+		if !book.StartAt.Valid {
+			return nil, domain.ErrMissingStartDate
+		}
+	}
+	// spreadsheets often keep only the date a book was finished
+	if book.EndAt.Valid && !book.StartAt.Valid {
+		book.StartAt = book.EndAt
+	}
+
+	if count := strings.TrimSpace(r.ReadCount); count != "" {
+		n, err := strconv.ParseUint(count, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid read count %q", count)
+		}
+		book.ReadCount = uint(n)
+	}
+	if pages := strings.TrimSpace(r.TotalPages); pages != "" {
+		n, err := strconv.ParseUint(pages, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid total pages %q", pages)
+		}
+		book.TotalPages = uint(n)
+	}
+	if rating := strings.TrimSpace(r.Rating); rating != "" {
+		f, err := strconv.ParseFloat(rating, 64)
+		if err != nil {
+			return nil, domain.ErrInvalidRating
+		}
+		book.Rating = domain.NewNullFloat(f)
+	}
+	return &book, nil
+}
+
+func parseImportDate(s string) (domain.NullTime, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return domain.NullTime{}, nil
+	}
+	for _, layout := range importDateLayouts {
+		t, err := time.ParseInLocation(layout, s, time.Local)
+		if err == nil {
+			return domain.NewNullTime(t), nil
+		}
+	}
+	return domain.NullTime{}, fmt.Errorf("invalid date %q", s)
+}
+
+// bookKeys identifies a book by its title and author name, ignoring case and surrounding space,
+// and by its ISBNs when known.
+func bookKeys(book domain.Book) []string {
+	name := ""
+	if book.Author != nil {
+		name = book.Author.Name
+	}
+	keys := []string{strings.ToLower(strings.TrimSpace(book.Title)) + "\x00" + strings.ToLower(strings.TrimSpace(name))}
+	if book.ISBN != "" {
+		keys = append(keys, "isbn:"+book.ISBN)
+	}
+	if book.ISBN13 != "" {
+		keys = append(keys, "isbn13:"+book.ISBN13)
+	}
+	return keys
+}
+
+func isSeen(seen map[string]bool, book domain.Book) bool {
+	for _, key := range bookKeys(book) {
+		if seen[key] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"bookshelf-web-api_gin_clean/api/usecases/usecasetest"
+)
+
+// tagUseCaseStub knows no tags; the records imported here carry none.
+type tagUseCaseStub struct {
+	usecases.TagUseCase
+}
+
+func (t tagUseCaseStub) GetAllTags(filter *usecases.Filter) (*domain.Tags, error) {
+	return &domain.Tags{}, nil
+}
+
+func TestImportClippingsAfterRestore(t *testing.T) {
+	bookRepo := &usecasetest.BookRepository{}
+	descRepo := &usecasetest.DescriptionRepository{}
+	u := usecases.NewImportUseCase(
+		usecases.NewBookUseCase(usecases.BookUseCaseDeps{BookRepo: bookRepo}),
+		usecases.NewDescriptionUseCase(descRepo, bookRepo),
+		tagUseCaseStub{},
+		bookRepo,
+		nil,
+	)
+	clipping := usecases.Clipping{Title: "Dune", Kind: usecases.HighlightClipping, Location: "100-102", Text: "Fear is the mind-killer."}
+
+	// a library exported after the clipping was imported, restored into an empty account
+	restored := []usecases.ImportRecord{{
+		Title:        "Dune",
+		Status:       "not_read",
+		Descriptions: domain.ExportedDescriptions{{Content: clipping.Content(), SourceKey: clipping.SourceKey()}},
+	}}
+	report, err := u.ImportBooks("account-a", restored)
+	if err != nil || report.Created != 1 {
+		t.Fatalf("restore: got %+v, %v", report, err)
+	}
+
+	report, err = u.ImportClippings("account-a", []usecases.Clipping{clipping})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Skipped != 1 || len(descRepo.Descriptions) != 1 {
+		t.Fatalf("got report %+v and descriptions %+v, want the clipping skipped", report, descRepo.Descriptions)
+	}
+}
+
+func TestFailInterruptedJobs(t *testing.T) {
+	jobRepo := &usecasetest.ImportJobRepository{}
+	for _, status := range []domain.JobStatus{domain.QueuedJob, domain.RunningJob, domain.FinishedJob, domain.FailedJob} {
+		job := domain.NewImportJob("account-a", "csv", 10)
+		job.Status = status
+		jobRepo.Create(job)
+	}
+	u := usecases.NewImportUseCase(nil, nil, nil, nil, jobRepo)
+
+	err := u.FailInterruptedJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []domain.JobStatus{domain.FailedJob, domain.FailedJob, domain.FinishedJob, domain.FailedJob}
+	for i, job := range jobRepo.Jobs {
+		if job.Status != want[i] {
+			t.Errorf("job %d: got status %s, want %s", job.ID, job.Status, want[i])
+		}
+	}
+	for _, job := range jobRepo.Jobs[:2] {
+		if job.Error != usecases.ErrImportInterrupted.Error() || !job.FinishedAt.Valid {
+			t.Errorf("job %d: got error %q and finished at %v", job.ID, job.Error, job.FinishedAt)
+		}
+	}
+	if jobRepo.Jobs[3].Error != "" {
+		t.Errorf("job %d: a job that had already failed got error %q", jobRepo.Jobs[3].ID, jobRepo.Jobs[3].Error)
+	}
+}
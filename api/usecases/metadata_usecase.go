@@ -0,0 +1,50 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type metadataUseCase struct {
+	BookUseCase BookUseCase
+	Provider    BookMetadataProvider
+}
+type MetadataUseCase interface {
+	LookupISBN(isbn string) (*domain.BookMetadata, error)
+	FillBook(filter *Filter) (*domain.Book, error)
+}
+
+func NewMetadataUseCase(bookUseCase BookUseCase, provider BookMetadataProvider) MetadataUseCase {
+	return &metadataUseCase{BookUseCase: bookUseCase, Provider: provider}
+}
+
+// LookupISBN asks the provider about an ISBN-10 or ISBN-13, which may be written with hyphens.
+func (m *metadataUseCase) LookupISBN(isbn string) (*domain.BookMetadata, error) {
+	isbn = domain.NormalizeISBN(isbn)
+	if len(isbn) == 10 {
+		if !domain.ValidISBN10(isbn) {
+			return nil, domain.ErrInvalidISBN10
+		}
+	} else if !domain.ValidISBN13(isbn) {
+		return nil, domain.ErrInvalidISBN13
+	}
+	return m.Provider.LookupISBN(isbn)
+}
+
+// FillBook looks the book up by its ISBN and fills in the fields that are still empty.
+func (m *metadataUseCase) FillBook(filter *Filter) (*domain.Book, error) {
+	book, err := m.BookUseCase.GetBook(filter)
+	if err != nil {
+		return nil, err
+	}
+	isbn := book.ISBN13
+	if isbn == "" {
+		isbn = book.ISBN
+	}
+	if isbn == "" {
+		return nil, domain.ErrMissingISBN
+	}
+	metadata, err := m.Provider.LookupISBN(isbn)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Fill(book)
+	return m.BookUseCase.UpdateBook(*book, filter)
+}
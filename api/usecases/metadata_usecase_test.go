@@ -0,0 +1,104 @@
+package usecases_test
+
+import (
+	"testing"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+	"bookshelf-web-api_gin_clean/api/usecases/usecasetest"
+)
+
+var dune = domain.BookMetadata{
+	ISBN:          "0441013597",
+	ISBN13:        "9780441013593",
+	Title:         "Dune",
+	Author:        "Frank Herbert",
+	Publisher:     "Ace",
+	PublishedDate: "2005",
+	TotalPages:    896,
+}
+
+// bookUseCaseStub serves GetBook and UpdateBook from a single book; FillBook needs nothing else.
+type bookUseCaseStub struct {
+	usecases.BookUseCase
+	book domain.Book
+}
+
+func (b *bookUseCaseStub) GetBook(filter *usecases.Filter) (*domain.Book, error) {
+	book := b.book
+	return &book, nil
+}
+
+func (b *bookUseCaseStub) UpdateBook(updateBook domain.Book, filter *usecases.Filter) (*domain.Book, error) {
+	err := updateBook.Validate()
+	if err != nil {
+		return nil, err
+	}
+	b.book = updateBook
+	return &updateBook, nil
+}
+
+func TestMetadataLookupISBN(t *testing.T) {
+	onlyISBN13 := domain.BookMetadata{ISBN13: "9791090636071", Title: "Le Petit Prince"}
+	u := usecases.NewMetadataUseCase(&bookUseCaseStub{}, &usecasetest.MetadataProvider{Books: []domain.BookMetadata{dune, onlyISBN13}})
+
+	tests := []struct {
+		isbn    string
+		title   string
+		wantErr error
+	}{
+		{isbn: "0441013597", title: "Dune"},
+		{isbn: "0-441-01359-7", title: "Dune"},
+		{isbn: "9780441013593", title: "Dune"},
+		{isbn: " 978-0-441-01359-3 ", title: "Dune"},
+		{isbn: "9791090636071", title: "Le Petit Prince"},
+		{isbn: "0441013598", wantErr: domain.ErrInvalidISBN10},
+		{isbn: "044101359X", wantErr: domain.ErrInvalidISBN10},
+		{isbn: "9780441013594", wantErr: domain.ErrInvalidISBN13},
+		{isbn: "9770441013594", wantErr: domain.ErrInvalidISBN13},
+		{isbn: "12345", wantErr: domain.ErrInvalidISBN13},
+		{isbn: "0306406152", wantErr: usecases.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.isbn, func(t *testing.T) {
+			book, err := u.LookupISBN(tt.isbn)
+			if err != tt.wantErr {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && book.Title != tt.title {
+				t.Fatalf("got title %q, want %q", book.Title, tt.title)
+			}
+		})
+	}
+}
+
+func TestMetadataFillBook(t *testing.T) {
+	provider := &usecasetest.MetadataProvider{Books: []domain.BookMetadata{dune}}
+
+	stub := &bookUseCaseStub{book: domain.Book{
+		Title:     "Dune (my copy)",
+		ReadState: domain.NotReadValue,
+		ISBN13:    "9780441013593",
+		Publisher: "Chilton",
+	}}
+	book, err := usecases.NewMetadataUseCase(stub, provider).FillBook(usecases.NewFilter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.Title != "Dune (my copy)" || book.Publisher != "Chilton" {
+		t.Errorf("filled fields already set: title %q, publisher %q", book.Title, book.Publisher)
+	}
+	if book.Author == nil || book.Author.Name != "Frank Herbert" || book.ISBN != "0441013597" ||
+		book.PublishedDate != "2005" || book.TotalPages != 896 {
+		t.Errorf("did not fill empty fields: %+v", book)
+	}
+	if stub.book.TotalPages != 896 {
+		t.Errorf("filled book was not stored")
+	}
+
+	stub = &bookUseCaseStub{book: domain.Book{Title: "Untitled", ReadState: domain.NotReadValue}}
+	_, err = usecases.NewMetadataUseCase(stub, provider).FillBook(usecases.NewFilter())
+	if err != domain.ErrMissingISBN {
+		t.Fatalf("got error %v, want %v", err, domain.ErrMissingISBN)
+	}
+}
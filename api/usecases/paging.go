@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Paging selects keyset pagination when Keyset is set, continuing from Cursor or from the start
+// when Cursor is nil, and offset pagination otherwise. Offset pagination returns every row
+// unless both Page and PerPage are set.
+type Paging struct {
+	Page    uint64
+	PerPage uint64
+	Keyset  bool
+	Cursor  *Cursor
+}
+
+func (p Paging) IsCursor() bool {
+	return p.Keyset || p.Cursor != nil
+}
+
+// Cursor is the position a keyset page continues from.
+// Values hold the sort key values of the row at that position, tie-broken by id.
+// Sort records the sort the cursor was issued for, so it cannot be replayed against another order.
+type Cursor struct {
+	Sort     string   `json:"s"`
+	Values   []string `json:"v"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// Encode returns the opaque form handed to clients.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	c := Cursor{}
+	err = json.Unmarshal(data, &c)
+	if err != nil || len(c.Values) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// String formats the sorts the way ParseSort reads them.
+func (s Sorts) String() string {
+	keys := make([]string, 0, len(s))
+	for _, v := range s {
+		if v.Desc {
+			keys = append(keys, "-"+string(v.Field))
+		} else {
+			keys = append(keys, string(v.Field))
+		}
+	}
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,8 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type ProgressRepository interface {
+	FindAll(filter *Filter) (*domain.ProgressEntries, error)
+	Create(entry domain.ProgressEntry) (*domain.ProgressEntry, error)
+}
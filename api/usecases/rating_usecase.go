@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"sort"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+)
+
+type ratingUseCase struct {
+	BookRepo BookRepository
+}
+type RatingUseCase interface {
+	GetAuthorRatings(accountId string) (*domain.RatingStats, error)
+	GetTagRatings(accountId string) (*domain.RatingStats, error)
+}
+
+func NewRatingUseCase(repo BookRepository) RatingUseCase {
+	return &ratingUseCase{BookRepo: repo}
+}
+
+func (r *ratingUseCase) GetAuthorRatings(accountId string) (*domain.RatingStats, error) {
+	books, err := r.ratedBooks(accountId)
+	if err != nil {
+		return nil, err
+	}
+	stats := map[uint64]*domain.RatingStat{}
+	for _, book := range books {
+		if book.Author == nil {
+			continue
+		}
+		addRating(stats, book.Author.ID, book.Author.Name, book.Rating.Float64)
+	}
+	return sortRatingStats(stats), nil
+}
+
+func (r *ratingUseCase) GetTagRatings(accountId string) (*domain.RatingStats, error) {
+	books, err := r.ratedBooks(accountId)
+	if err != nil {
+		return nil, err
+	}
+	stats := map[uint64]*domain.RatingStat{}
+	for _, book := range books {
+		for _, tag := range book.Tags {
+			addRating(stats, tag.ID, tag.Name, book.Rating.Float64)
+		}
+	}
+	return sortRatingStats(stats), nil
+}
+
+func (r *ratingUseCase) ratedBooks(accountId string) (domain.Books, error) {
+	filter := NewFilter().NotNull(RatingField)
+	ByAccountId(filter, accountId)
+	books, err := r.BookRepo.FindAll(filter, Paging{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return books.Books, nil
+}
+
+func addRating(stats map[uint64]*domain.RatingStat, id uint64, name string, rating float64) {
+	stat, ok := stats[id]
+	if !ok {
+		stat = &domain.RatingStat{ID: id, Name: name}
+		stats[id] = stat
+	}
+	stat.Add(rating)
+}
+
+// sortRatingStats orders the stats best rated first.
+func sortRatingStats(stats map[uint64]*domain.RatingStat) *domain.RatingStats {
+	sorted := make(domain.RatingStats, 0, len(stats))
+	for _, v := range stats {
+		sorted = append(sorted, *v)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Average != sorted[j].Average {
+			return sorted[i].Average > sorted[j].Average
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return &sorted
+}
@@ -0,0 +1,10 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type ReadingSessionRepository interface {
+	FindAll(filter *Filter) (*domain.ReadingSessions, error)
+	Find(filter *Filter) (*domain.ReadingSession, error)
+	Create(session domain.ReadingSession) (*domain.ReadingSession, error)
+	Store(session domain.ReadingSession) error
+}
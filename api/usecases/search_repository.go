@@ -0,0 +1,34 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+// SearchWeights are the scores of a term matched in each field.
+type SearchWeights struct {
+	Title       float64
+	Author      float64
+	Description float64
+}
+
+// SearchQuery matches lower-cased Terms against the books of an account and their descriptions.
+// A field scores its weight for each term it contains, and once more for containing Phrase
+// when there is more than one term.
+type SearchQuery struct {
+	AccountID string
+	Terms     []string
+	Phrase    string
+	Weights   SearchWeights
+}
+
+// SearchMatch is a ranked hit: a book, or a description of the book when DescriptionID is set.
+type SearchMatch struct {
+	Type          domain.SearchHitType
+	BookID        uint64
+	DescriptionID uint64
+	Score         float64
+}
+
+// SearchRepository ranks the matches by score, then by the last update of their book,
+// and returns one page of them with the total count.
+type SearchRepository interface {
+	Search(query SearchQuery, page, perPage uint64) ([]SearchMatch, int64, error)
+}
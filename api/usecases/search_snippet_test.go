@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnippet(t *testing.T) {
+	long := strings.Repeat("a", 100)
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  string
+	}{
+		{"match at the start", "Dune is a novel", []string{"dune"}, "<em>Dune</em> is a novel"},
+		{"match at the end", "a novel called Dune", []string{"dune"}, "a novel called <em>Dune</em>"},
+		{"whole text", "Dune", []string{"dune"}, "<em>Dune</em>"},
+		{"every match marked", "dune, Dune, DUNE", []string{"dune"}, "<em>dune</em>, <em>Dune</em>, <em>DUNE</em>"},
+		{"longest term wins", "Dunes", []string{"dune", "dunes"}, "<em>Dunes</em>"},
+		{"no match", "Emma", []string{"dune"}, "Emma"},
+		{"HTML escaped", `<b>Dune</b> & "Emma"`, []string{"dune"}, `&lt;b&gt;<em>Dune</em>&lt;/b&gt; &amp; &#34;Emma&#34;`},
+		{"HTML in a match escaped", "a <i> tag", []string{"<i>"}, "a <em>&lt;i&gt;</em> tag"},
+		{"entity text is not a match", "fish &amp; chips", []string{"amp"}, "fish &amp;<em>amp</em>; chips"},
+		{"runes", "Émile Zola", []string{"émile"}, "<em>Émile</em> Zola"},
+		{"cut before", long + "Dune", []string{"dune"}, "…" + strings.Repeat("a", 60) + "<em>Dune</em>"},
+		{"cut after", "Dune" + long, []string{"dune"}, "<em>Dune</em>" + strings.Repeat("a", 56) + "…"},
+		{"cut on both sides", long + "Dune" + long, []string{"dune"},
+			"…" + strings.Repeat("a", 60) + "<em>Dune</em>" + strings.Repeat("a", 56) + "…"},
+		{"match cut at the edge left unmarked", "Dune" + strings.Repeat("a", 55) + "Dune", []string{"dune"},
+			"<em>Dune</em>" + strings.Repeat("a", 55) + "D…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snippet(tt.text, tt.terms); got != tt.want {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package usecases
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"unicode"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+)
+
+var ErrEmptyQuery = errors.New("empty search query")
+
+// weights of a term matched in each field; a match of the whole query counts once more on top.
+const (
+	titleWeight       = 3.0
+	authorWeight      = 2.0
+	descriptionWeight = 1.0
+)
+
+// snippetRadius is how many characters of context a snippet keeps around the first match.
+const snippetRadius = 60
+
+// searchPerPage is the page size when none is given, and maxSearchPerPage the largest one served.
+const (
+	searchPerPage    = 20
+	maxSearchPerPage = 100
+)
+
+type searchUseCase struct {
+	SearchRepo      SearchRepository
+	BookRepo        BookRepository
+	DescriptionRepo DescriptionRepository
+}
+type SearchUseCase interface {
+	Search(accountId string, query string, page, perPage uint64) (*domain.PaginateSearchHits, error)
+}
+
+func NewSearchUseCase(searchRepo SearchRepository, bookRepo BookRepository, descRepo DescriptionRepository) SearchUseCase {
+	return &searchUseCase{SearchRepo: searchRepo, BookRepo: bookRepo, DescriptionRepo: descRepo}
+}
+
+// Search matches the query terms against book titles, author names and description contents of the account,
+// and returns one page of the hits ranked by score. Results are always paged: page defaults to the first
+// and perPage to searchPerPage, capped at maxSearchPerPage.
+func (s *searchUseCase) Search(accountId string, query string, page, perPage uint64) (*domain.PaginateSearchHits, error) {
+	terms := strings.Fields(lowerRunes(query))
+	if len(terms) == 0 {
+		return nil, ErrEmptyQuery
+	}
+	phrase := strings.Join(terms, " ")
+	if page == 0 {
+		page = 1
+	}
+	if perPage == 0 {
+		perPage = searchPerPage
+	} else if perPage > maxSearchPerPage {
+		perPage = maxSearchPerPage
+	}
+
+	matches, total, err := s.SearchRepo.Search(SearchQuery{
+		AccountID: accountId,
+		Terms:     terms,
+		Phrase:    phrase,
+		Weights:   SearchWeights{Title: titleWeight, Author: authorWeight, Description: descriptionWeight},
+	}, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+	result := domain.PaginateSearchHits{Hits: domain.SearchHits{}, TotalCount: total}
+	if len(matches) == 0 {
+		return &result, nil
+	}
+
+	bookIds := make([]uint64, 0, len(matches))
+	descriptionIds := make([]uint64, 0, len(matches))
+	for _, v := range matches {
+		bookIds = append(bookIds, v.BookID)
+		if v.DescriptionID != 0 {
+			descriptionIds = append(descriptionIds, v.DescriptionID)
+		}
+	}
+	bookFilter := NewFilter().In(IdField, bookIds)
+	ByAccountId(bookFilter, accountId)
+	books, err := s.BookRepo.FindAll(bookFilter, Paging{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	booksById := map[uint64]*domain.Book{}
+	for i := range books.Books {
+		booksById[books.Books[i].ID] = &books.Books[i]
+	}
+	descriptionsById := map[uint64]*domain.Description{}
+	if len(descriptionIds) > 0 {
+		descriptions, err := s.DescriptionRepo.FindAll(NewFilter().In(IdField, descriptionIds), Paging{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range descriptions.Descriptions {
+			descriptionsById[descriptions.Descriptions[i].ID] = &descriptions.Descriptions[i]
+		}
+	}
+
+	for _, v := range matches {
+		book, ok := booksById[v.BookID]
+		if !ok {
+			continue
+		}
+		hit := domain.SearchHit{Type: v.Type, Score: v.Score, Book: book, Snippets: []domain.Snippet{}}
+		if v.Type == domain.DescriptionHitType {
+			description, ok := descriptionsById[v.DescriptionID]
+			if !ok {
+				continue
+			}
+			hit.Description = description
+			hit.Snippets = append(hit.Snippets, domain.Snippet{Field: "content", Text: snippet(description.Content, terms)})
+		} else {
+			if matchScore(book.Title, terms, phrase, titleWeight) > 0 {
+				hit.Snippets = append(hit.Snippets, domain.Snippet{Field: "title", Text: snippet(book.Title, terms)})
+			}
+			if book.Author != nil && matchScore(book.Author.Name, terms, phrase, authorWeight) > 0 {
+				hit.Snippets = append(hit.Snippets, domain.Snippet{Field: "author", Text: snippet(book.Author.Name, terms)})
+			}
+		}
+		result.Hits = append(result.Hits, hit)
+	}
+	return &result, nil
+}
+
+// lowerRunes lower-cases s rune by rune, so rune offsets stay the same as in s.
+func lowerRunes(s string) string {
+	return strings.Map(unicode.ToLower, s)
+}
+
+func matchScore(text string, terms []string, phrase string, weight float64) float64 {
+	lower := lowerRunes(text)
+	score := 0.0
+	for _, term := range terms {
+		if strings.Contains(lower, term) {
+			score += weight
+		}
+	}
+	if len(terms) > 1 && strings.Contains(lower, phrase) {
+		score += weight
+	}
+	return score
+}
+
+// snippet cuts text around its first match and marks every match inside the cut.
+func snippet(text string, terms []string) string {
+	runes := []rune(text)
+	lower := []rune(lowerRunes(text))
+
+	first := -1
+	for _, term := range terms {
+		if i := runeIndex(lower, []rune(term), 0); i >= 0 && (first < 0 || i < first) {
+			first = i
+		}
+	}
+	if first < 0 {
+		first = 0
+	}
+	from := first - snippetRadius
+	if from < 0 {
+		from = 0
+	}
+	to := first + snippetRadius
+	if to > len(runes) {
+		to = len(runes)
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	for i := from; i < to; {
+		matched := 0
+		for _, term := range terms {
+			t := []rune(term)
+			if len(t) > matched && i+len(t) <= to && runesAt(lower, t, i) {
+				matched = len(t)
+			}
+		}
+		if matched == 0 {
+			b.WriteString(html.EscapeString(string(runes[i])))
+			i++
+			continue
+		}
+		b.WriteString("<em>")
+		b.WriteString(html.EscapeString(string(runes[i : i+matched])))
+		b.WriteString("</em>")
+		i += matched
+	}
+	if to < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+func runeIndex(s []rune, sub []rune, from int) int {
+	for i := from; i+len(sub) <= len(s); i++ {
+		if runesAt(s, sub, i) {
+			return i
+		}
+	}
+	return -1
+}
+
+func runesAt(s []rune, sub []rune, at int) bool {
+	if at+len(sub) > len(s) {
+		return false
+	}
+	for j := range sub {
+		if s[at+j] != sub[j] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,13 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type SeriesRepository interface {
+	FindAll(filter *Filter) (*domain.SeriesList, error)
+	Find(filter *Filter) (*domain.Series, error)
+	Create(series domain.Series) (*domain.Series, error)
+	Delete(filter *Filter) error
+	Store(series domain.Series) error
+	FindMembers(filter *Filter) (*domain.SeriesBooks, error)
+	StoreMembers(seriesId uint64, bookIds []uint64) error
+}
@@ -0,0 +1,251 @@
+package usecases
+
+import (
+	"errors"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+)
+
+var ErrInvalidOrder = errors.New("book order must list every book of the series once")
+
+type seriesUseCase struct {
+	SeriesRepo SeriesRepository
+	BookRepo   BookRepository
+}
+type SeriesUseCase interface {
+	GetAllSeries(filter *Filter) (*domain.SeriesList, error)
+	GetSeries(filter *Filter) (*domain.Series, error)
+	CreateSeries(createSeries domain.Series) (*domain.Series, error)
+	UpdateSeries(updateSeries domain.Series, filter *Filter) (*domain.Series, error)
+	DeleteSeries(filter *Filter) error
+	InsertBook(filter *Filter, bookId uint64, position int) (*domain.Series, error)
+	ReorderBooks(filter *Filter, bookIds []uint64) (*domain.Series, error)
+	RemoveBook(filter *Filter, bookId uint64) (*domain.Series, error)
+	GetNextBooks(accountId string) (*domain.Books, error)
+}
+
+func NewSeriesUseCase(seriesRepo SeriesRepository, bookRepo BookRepository) SeriesUseCase {
+	return &seriesUseCase{SeriesRepo: seriesRepo, BookRepo: bookRepo}
+}
+
+func (s *seriesUseCase) GetAllSeries(filter *Filter) (*domain.SeriesList, error) {
+	seriesList, err := s.SeriesRepo.FindAll(filter)
+	if err != nil {
+		return nil, err
+	}
+	for i := range *seriesList {
+		err = s.loadBooks(&(*seriesList)[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return seriesList, nil
+}
+
+func (s *seriesUseCase) GetSeries(filter *Filter) (*domain.Series, error) {
+	series, err := s.SeriesRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	err = s.loadBooks(series)
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (s *seriesUseCase) CreateSeries(createSeries domain.Series) (*domain.Series, error) {
+	err := createSeries.Validate()
+	if err != nil {
+		return nil, err
+	}
+	newSeries, err := s.SeriesRepo.Create(createSeries)
+	if err != nil {
+		return nil, err
+	}
+	newSeries.Books = domain.Books{}
+	return newSeries, nil
+}
+
+func (s *seriesUseCase) UpdateSeries(updateSeries domain.Series, filter *Filter) (*domain.Series, error) {
+	_, err := s.SeriesRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	err = updateSeries.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = s.SeriesRepo.Store(updateSeries)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSeries(filter)
+}
+
+func (s *seriesUseCase) DeleteSeries(filter *Filter) error {
+	return s.SeriesRepo.Delete(filter)
+}
+
+// InsertBook puts the book at position (counting from 1) in the series, or at the end when position is out of range.
+// A book already in the series is moved; a book of another series is rejected with ErrAlreadyExists.
+func (s *seriesUseCase) InsertBook(filter *Filter, bookId uint64, position int) (*domain.Series, error) {
+	series, err := s.SeriesRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	bookFilter := NewFilter()
+	ById(bookFilter, bookId)
+	ByAccountId(bookFilter, series.AccountID)
+	_, err = s.BookRepo.Find(bookFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := s.SeriesRepo.FindMembers(NewFilter().Eq(BookIdField, bookId))
+	if err != nil {
+		return nil, err
+	}
+	if len(*membership) > 0 && (*membership)[0].SeriesId != series.ID {
+		return nil, ErrAlreadyExists
+	}
+
+	bookIds, err := s.memberIds(series.ID)
+	if err != nil {
+		return nil, err
+	}
+	bookIds = removeId(bookIds, bookId)
+	if position < 1 || position > len(bookIds) {
+		position = len(bookIds) + 1
+	}
+	bookIds = append(bookIds[:position-1], append([]uint64{bookId}, bookIds[position-1:]...)...)
+
+	err = s.SeriesRepo.StoreMembers(series.ID, bookIds)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSeries(filter)
+}
+
+// ReorderBooks sets the reading order of the series. bookIds must hold exactly the books of the series.
+func (s *seriesUseCase) ReorderBooks(filter *Filter, bookIds []uint64) (*domain.Series, error) {
+	series, err := s.SeriesRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.memberIds(series.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(current) != len(bookIds) {
+		return nil, ErrInvalidOrder
+	}
+	remaining := map[uint64]bool{}
+	for _, id := range current {
+		remaining[id] = true
+	}
+	for _, id := range bookIds {
+		if !remaining[id] {
+			return nil, ErrInvalidOrder
+		}
+		delete(remaining, id)
+	}
+
+	err = s.SeriesRepo.StoreMembers(series.ID, bookIds)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSeries(filter)
+}
+
+func (s *seriesUseCase) RemoveBook(filter *Filter, bookId uint64) (*domain.Series, error) {
+	series, err := s.SeriesRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.memberIds(series.ID)
+	if err != nil {
+		return nil, err
+	}
+	bookIds := removeId(current, bookId)
+	if len(bookIds) == len(current) {
+		return nil, ErrNotFound
+	}
+
+	err = s.SeriesRepo.StoreMembers(series.ID, bookIds)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSeries(filter)
+}
+
+// GetNextBooks returns, for every series of the account, the first volume not read yet.
+// Series whose volumes are all read are skipped.
+func (s *seriesUseCase) GetNextBooks(accountId string) (*domain.Books, error) {
+	seriesFilter := NewFilter()
+	ByAccountId(seriesFilter, accountId)
+	seriesList, err := s.GetAllSeries(seriesFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	next := make(domain.Books, 0)
+	for _, series := range *seriesList {
+		for _, book := range series.Books {
+			if book.ReadState != domain.ReadValue {
+				next = append(next, book)
+				break
+			}
+		}
+	}
+	return &next, nil
+}
+
+func (s *seriesUseCase) memberIds(seriesId uint64) ([]uint64, error) {
+	members, err := s.SeriesRepo.FindMembers(NewFilter().Eq(SeriesIdField, seriesId))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint64, 0, len(*members))
+	for _, v := range *members {
+		ids = append(ids, v.BookId)
+	}
+	return ids, nil
+}
+
+// loadBooks fills series.Books in reading order.
+func (s *seriesUseCase) loadBooks(series *domain.Series) error {
+	series.Books = domain.Books{}
+	ids, err := s.memberIds(series.ID)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	bookFilter := NewFilter().In(IdField, ids)
+	ByAccountId(bookFilter, series.AccountID)
+	books, err := s.BookRepo.FindAll(bookFilter, Paging{}, nil)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		for _, book := range books.Books {
+			if book.ID == id {
+				series.Books = append(series.Books, book)
+			}
+		}
+	}
+	return nil
+}
+
+func removeId(ids []uint64, id uint64) []uint64 {
+	removed := make([]uint64, 0, len(ids))
+	for _, v := range ids {
+		if v != id {
+			removed = append(removed, v)
+		}
+	}
+	return removed
+}
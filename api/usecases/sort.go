@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+// Sort orders by Field. Rows where a Nullable field is NULL come last in either direction,
+// or first when NullsFirst is set, as it is when a keyset page is read backwards.
+type Sort struct {
+	Field      Field
+	Desc       bool
+	Nullable   bool
+	NullsFirst bool
+}
+
+type Sorts []Sort
+
+// SortableField is a field clients are allowed to sort on.
+type SortableField struct {
+	Field    Field
+	Nullable bool
+}
+
+// BookSortFields is the allowlist of sort keys accepted for books, keyed by their name in the API.
+var BookSortFields = map[string]SortableField{
+	"id":         {Field: IdField},
+	"title":      {Field: TitleField},
+	"read_state": {Field: ReadStateField},
+	"read_count": {Field: ReadCountField},
+	"rating":     {Field: RatingField, Nullable: true},
+	"start_at":   {Field: StartAtField, Nullable: true},
+	"end_at":     {Field: EndAtField, Nullable: true},
+	"created_at": {Field: CreatedAtField},
+	"updated_at": {Field: UpdatedAtField},
+}
+
+// ParseSort reads a comma separated list of sort keys such as "-end_at,title".
+// A leading "-" sorts descending. Keys missing from allowed or repeated return ErrInvalidSort.
+func ParseSort(s string, allowed map[string]SortableField) (Sorts, error) {
+	sorts := make(Sorts, 0)
+	if strings.TrimSpace(s) == "" {
+		return sorts, nil
+	}
+	seen := map[string]bool{}
+	for _, key := range strings.Split(s, ",") {
+		key = strings.TrimSpace(key)
+		desc := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(key, "-")
+		field, ok := allowed[key]
+		if !ok || seen[key] {
+			return nil, ErrInvalidSort
+		}
+		seen[key] = true
+		sorts = append(sorts, Sort{Field: field.Field, Desc: desc, Nullable: field.Nullable})
+	}
+	return sorts, nil
+}
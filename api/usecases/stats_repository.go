@@ -0,0 +1,20 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+// StatsPeriod is the length of the periods finished books are grouped by.
+type StatsPeriod string
+
+const (
+	MonthPeriod StatsPeriod = "month"
+	YearPeriod  StatsPeriod = "year"
+)
+
+// StatsRepository aggregates over the books matching a filter.
+type StatsRepository interface {
+	FinishedPerPeriod(filter *Filter, period StatsPeriod) (*domain.PeriodStats, error)
+	CountBooks(filter *Filter) (int64, error)
+	TopAuthors(filter *Filter, limit uint64) (*domain.RankedStats, error)
+	TopTags(filter *Filter, limit uint64) (*domain.RankedStats, error)
+	FindReadingPeriods(filter *Filter) (*domain.ReadingPeriods, error)
+}
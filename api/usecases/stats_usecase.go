@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"time"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+)
+
+// topStatsLimit is how many authors and tags the stats rank.
+const topStatsLimit = 5
+
+type statsUseCase struct {
+	StatsRepo StatsRepository
+}
+type StatsUseCase interface {
+	GetStats(accountId string) (*domain.ReadingStats, error)
+}
+
+func NewStatsUseCase(repo StatsRepository) StatsUseCase {
+	return &statsUseCase{StatsRepo: repo}
+}
+
+func (s *statsUseCase) GetStats(accountId string) (*domain.ReadingStats, error) {
+	finished := NewFilter().NotNull(EndAtField)
+	ByAccountId(finished, accountId)
+	ByStatus(finished, domain.ReadValue)
+
+	perMonth, err := s.StatsRepo.FinishedPerPeriod(finished, MonthPeriod)
+	if err != nil {
+		return nil, err
+	}
+	perYear, err := s.StatsRepo.FinishedPerPeriod(finished, YearPeriod)
+	if err != nil {
+		return nil, err
+	}
+	topAuthors, err := s.StatsRepo.TopAuthors(finished, topStatsLimit)
+	if err != nil {
+		return nil, err
+	}
+	topTags, err := s.StatsRepo.TopTags(finished, topStatsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	reading := NewFilter()
+	ByAccountId(reading, accountId)
+	ByStatus(reading, domain.ReadingValue)
+	readingCount, err := s.StatsRepo.CountBooks(reading)
+	if err != nil {
+		return nil, err
+	}
+
+	// books given up on without an end date cannot tell how long they were read
+	started := NewFilter().NotNull(StartAtField)
+	ByAccountId(started, accountId)
+	started.Or(NewFilter().NotNull(EndAtField), NewFilter().Eq(ReadStateField, domain.ReadingValue))
+	periods, err := s.StatsRepo.FindReadingPeriods(started)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ReadingStats{
+		FinishedPerMonth:    *perMonth,
+		FinishedPerYear:     *perYear,
+		AverageDaysToFinish: periods.AverageDaysToFinish(),
+		CurrentlyReading:    readingCount,
+		TopAuthors:          *topAuthors,
+		TopTags:             *topTags,
+		LongestStreak:       periods.LongestStreak(time.Now()),
+	}, nil
+}
@@ -0,0 +1,13 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type TagRepository interface {
+	FindAll(filter *Filter) (*domain.Tags, error)
+	Find(filter *Filter) (*domain.Tag, error)
+	Create(tag domain.Tag) (*domain.Tag, error)
+	Delete(filter *Filter) error
+	Store(tag domain.Tag) error
+	FindBookIds(tagIds []uint64, matchAll bool) ([]uint64, error)
+	StoreBookTags(bookId uint64, tagIds []uint64) error
+}
@@ -0,0 +1,92 @@
+package usecases
+
+import "bookshelf-web-api_gin_clean/api/domain"
+
+type tagUseCase struct {
+	TagRepo TagRepository
+}
+type TagUseCase interface {
+	GetAllTags(filter *Filter) (*domain.Tags, error)
+	GetTag(filter *Filter) (*domain.Tag, error)
+	CreateTag(createTag domain.Tag) (*domain.Tag, error)
+	UpdateTag(updateTag domain.Tag, filter *Filter) (*domain.Tag, error)
+	DeleteTag(filter *Filter) error
+}
+
+func NewTagUseCase(repo TagRepository) TagUseCase {
+	return &tagUseCase{TagRepo: repo}
+}
+
+func (t *tagUseCase) GetAllTags(filter *Filter) (*domain.Tags, error) {
+	tags, err := t.TagRepo.FindAll(filter)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (t *tagUseCase) GetTag(filter *Filter) (*domain.Tag, error) {
+	tag, err := t.TagRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (t *tagUseCase) CreateTag(createTag domain.Tag) (*domain.Tag, error) {
+	err := createTag.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = t.checkDuplicate(createTag)
+	if err != nil {
+		return nil, err
+	}
+	newTag, err := t.TagRepo.Create(createTag)
+	if err != nil {
+		return nil, err
+	}
+	return newTag, nil
+}
+
+func (t *tagUseCase) UpdateTag(updateTag domain.Tag, filter *Filter) (*domain.Tag, error) {
+	_, err := t.TagRepo.Find(filter)
+	if err != nil {
+		return nil, err
+	}
+	err = updateTag.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = t.checkDuplicate(updateTag)
+	if err != nil {
+		return nil, err
+	}
+	err = t.TagRepo.Store(updateTag)
+	if err != nil {
+		return nil, err
+	}
+	return t.TagRepo.Find(filter)
+}
+
+func (t *tagUseCase) DeleteTag(filter *Filter) error {
+	return t.TagRepo.Delete(filter)
+}
+
+// checkDuplicate rejects a name that another tag of the same account already uses.
+func (t *tagUseCase) checkDuplicate(tag domain.Tag) error {
+	filter := NewFilter()
+	ByAccountId(filter, tag.AccountID)
+	ByName(filter, tag.Name)
+	found, err := t.TagRepo.Find(filter)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if found.ID != tag.ID {
+		return ErrAlreadyExists
+	}
+	return nil
+}
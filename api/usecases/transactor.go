@@ -0,0 +1,13 @@
+package usecases
+
+// TxRepositories are repositories bound to a single database transaction.
+type TxRepositories struct {
+	Books    BookRepository
+	Sessions ReadingSessionRepository
+}
+
+// Transactor runs fn inside a database transaction, committing it when fn returns nil
+// and rolling it back when fn returns an error.
+type Transactor interface {
+	Transaction(fn func(repos TxRepositories) error) error
+}
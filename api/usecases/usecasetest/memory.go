@@ -0,0 +1,415 @@
+// Package usecasetest holds in-memory repositories for testing use cases and controllers without a database.
+package usecasetest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"bookshelf-web-api_gin_clean/api/domain"
+	"bookshelf-web-api_gin_clean/api/usecases"
+)
+
+// Match reports whether a row with the given column values passes the filter.
+// It understands every operator but LIKE and panics on that, so a test never passes by accident
+// on a condition it cannot check. A nil column value is NULL and, as in SQL, only matches IS NULL,
+// or = and <> against nil, which the database translates to IS NULL and IS NOT NULL.
+func Match(filter *usecases.Filter, row map[usecases.Field]interface{}) bool {
+	if filter == nil || filter.IsEmpty() {
+		return true
+	}
+	results := make([]bool, 0, len(filter.Conditions)+len(filter.Groups))
+	for _, c := range filter.Conditions {
+		results = append(results, matchCondition(c, row))
+	}
+	for _, g := range filter.Groups {
+		if !g.IsEmpty() {
+			results = append(results, Match(g, row))
+		}
+	}
+	for _, r := range results {
+		if filter.Conjunction == usecases.OrConjunction && r {
+			return true
+		}
+		if filter.Conjunction != usecases.OrConjunction && !r {
+			return false
+		}
+	}
+	return filter.Conjunction != usecases.OrConjunction
+}
+
+func matchCondition(c usecases.Condition, row map[usecases.Field]interface{}) bool {
+	value, ok := row[c.Field]
+	if !ok {
+		panic(fmt.Sprintf("usecasetest: no column %s", c.Field))
+	}
+	if c.Value == nil && (c.Operator == usecases.EqOperator || c.Operator == usecases.NotEqOperator) {
+		return (value == nil) == (c.Operator == usecases.EqOperator)
+	}
+	if value == nil && c.Operator != usecases.IsNullOperator && c.Operator != usecases.NotNullOperator {
+		return false
+	}
+	switch c.Operator {
+	case usecases.EqOperator:
+		return equal(value, c.Value)
+	case usecases.NotEqOperator:
+		return !equal(value, c.Value)
+	case usecases.GtOperator:
+		return compare(value, c.Value) > 0
+	case usecases.GteOperator:
+		return compare(value, c.Value) >= 0
+	case usecases.LtOperator:
+		return compare(value, c.Value) < 0
+	case usecases.LteOperator:
+		return compare(value, c.Value) <= 0
+	case usecases.InOperator:
+		values := reflect.ValueOf(c.Value)
+		for i := 0; i < values.Len(); i++ {
+			if equal(value, values.Index(i).Interface()) {
+				return true
+			}
+		}
+		return false
+	case usecases.IsNullOperator:
+		return value == nil
+	case usecases.NotNullOperator:
+		return value != nil
+	default:
+		panic(fmt.Sprintf("usecasetest: operator %s is not supported", c.Operator))
+	}
+}
+
+// equal compares values the way the database would, ignoring the Go type of numbers.
+func equal(a, b interface{}) bool {
+	return compare(a, b) == 0
+}
+
+// compare orders numbers by value and anything else by its text.
+func compare(a, b interface{}) int {
+	as, bs := fmt.Sprint(a), fmt.Sprint(b)
+	af, aErr := strconv.ParseFloat(as, 64)
+	bf, bErr := strconv.ParseFloat(bs, 64)
+	if aErr == nil && bErr == nil {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case as < bs:
+		return -1
+	case as > bs:
+		return 1
+	}
+	return 0
+}
+
+func bookRow(b domain.Book) map[usecases.Field]interface{} {
+	return map[usecases.Field]interface{}{
+		usecases.IdField:        b.ID,
+		usecases.AccountIdField: b.AccountID,
+		usecases.TitleField:     b.Title,
+		usecases.ReadStateField: int8(b.ReadState),
+	}
+}
+
+// BookRepository keeps books in memory. FindAll ignores paging and sorts and returns books in insertion order.
+type BookRepository struct {
+	Books  domain.Books
+	nextId uint64
+}
+
+func (r *BookRepository) FindAll(filter *usecases.Filter, paging usecases.Paging, sorts usecases.Sorts) (*domain.PaginateBooks, error) {
+	books := make(domain.Books, 0)
+	for _, v := range r.Books {
+		if Match(filter, bookRow(v)) {
+			books = append(books, v)
+		}
+	}
+	return &domain.PaginateBooks{Books: books, TotalCount: int64(len(books))}, nil
+}
+
+func (r *BookRepository) Find(filter *usecases.Filter) (*domain.Book, error) {
+	for _, v := range r.Books {
+		if Match(filter, bookRow(v)) {
+			book := v
+			return &book, nil
+		}
+	}
+	return nil, usecases.ErrNotFound
+}
+
+func (r *BookRepository) Create(book domain.Book) (*domain.Book, error) {
+	for _, v := range r.Books {
+		if v.ID > r.nextId {
+			r.nextId = v.ID
+		}
+	}
+	r.nextId++
+	book.ID = r.nextId
+	r.Books = append(r.Books, book)
+	return &book, nil
+}
+
+func (r *BookRepository) Delete(filter *usecases.Filter) error {
+	for i, v := range r.Books {
+		if Match(filter, bookRow(v)) {
+			r.Books = append(r.Books[:i], r.Books[i+1:]...)
+			return nil
+		}
+	}
+	return usecases.ErrNotFound
+}
+
+func (r *BookRepository) Store(book domain.Book, filter *usecases.Filter) error {
+	for i, v := range r.Books {
+		if Match(filter, bookRow(v)) {
+			r.Books[i] = book
+			return nil
+		}
+	}
+	return usecases.ErrNotFound
+}
+
+func descriptionRow(d domain.Description) map[usecases.Field]interface{} {
+	return map[usecases.Field]interface{}{
+		usecases.IdField:     d.ID,
+		usecases.BookIdField: d.BookId,
+	}
+}
+
+// DescriptionRepository keeps descriptions in memory. FindAll ignores paging.
+type DescriptionRepository struct {
+	Descriptions domain.Descriptions
+	nextId       uint64
+}
+
+func (r *DescriptionRepository) FindAll(filter *usecases.Filter, paging usecases.Paging) (*domain.PaginateDescriptions, error) {
+	descriptions := make(domain.Descriptions, 0)
+	for _, v := range r.Descriptions {
+		if Match(filter, descriptionRow(v)) {
+			descriptions = append(descriptions, v)
+		}
+	}
+	return &domain.PaginateDescriptions{Descriptions: descriptions, TotalCount: int64(len(descriptions))}, nil
+}
+
+func (r *DescriptionRepository) Find(filter *usecases.Filter) (*domain.Description, error) {
+	for _, v := range r.Descriptions {
+		if Match(filter, descriptionRow(v)) {
+			description := v
+			return &description, nil
+		}
+	}
+	return nil, usecases.ErrNotFound
+}
+
+func (r *DescriptionRepository) Create(description domain.Description) (*domain.Description, error) {
+	for _, v := range r.Descriptions {
+		if v.ID > r.nextId {
+			r.nextId = v.ID
+		}
+	}
+	r.nextId++
+	description.ID = r.nextId
+	r.Descriptions = append(r.Descriptions, description)
+	return &description, nil
+}
+
+func (r *DescriptionRepository) Delete(description domain.Description) error {
+	for i, v := range r.Descriptions {
+		if v.ID == description.ID {
+			r.Descriptions = append(r.Descriptions[:i], r.Descriptions[i+1:]...)
+			return nil
+		}
+	}
+	return usecases.ErrNotFound
+}
+
+func (r *DescriptionRepository) Store(description domain.Description) error {
+	for i, v := range r.Descriptions {
+		if v.ID == description.ID {
+			r.Descriptions[i] = description
+			return nil
+		}
+	}
+	return usecases.ErrNotFound
+}
+
+func highlightRow(h domain.Highlight) map[usecases.Field]interface{} {
+	return map[usecases.Field]interface{}{
+		usecases.IdField:     h.ID,
+		usecases.BookIdField: h.BookId,
+	}
+}
+
+// HighlightRepository keeps highlights in memory.
+type HighlightRepository struct {
+	Highlights domain.Highlights
+	nextId     uint64
+}
+
+func (r *HighlightRepository) FindAll(filter *usecases.Filter) (*domain.Highlights, error) {
+	highlights := make(domain.Highlights, 0)
+	for _, v := range r.Highlights {
+		if Match(filter, highlightRow(v)) {
+			highlights = append(highlights, v)
+		}
+	}
+	return &highlights, nil
+}
+
+func (r *HighlightRepository) Find(filter *usecases.Filter) (*domain.Highlight, error) {
+	for _, v := range r.Highlights {
+		if Match(filter, highlightRow(v)) {
+			highlight := v
+			return &highlight, nil
+		}
+	}
+	return nil, usecases.ErrNotFound
+}
+
+func (r *HighlightRepository) Create(highlight domain.Highlight) (*domain.Highlight, error) {
+	for _, v := range r.Highlights {
+		if v.ID > r.nextId {
+			r.nextId = v.ID
+		}
+	}
+	r.nextId++
+	highlight.ID = r.nextId
+	r.Highlights = append(r.Highlights, highlight)
+	return &highlight, nil
+}
+
+func (r *HighlightRepository) Delete(highlight domain.Highlight) error {
+	for i, v := range r.Highlights {
+		if v.ID == highlight.ID {
+			r.Highlights = append(r.Highlights[:i], r.Highlights[i+1:]...)
+			return nil
+		}
+	}
+	return usecases.ErrNotFound
+}
+
+func (r *HighlightRepository) Store(highlight domain.Highlight) error {
+	for i, v := range r.Highlights {
+		if v.ID == highlight.ID {
+			r.Highlights[i] = highlight
+			return nil
+		}
+	}
+	return usecases.ErrNotFound
+}
+
+func importJobRow(j domain.ImportJob) map[usecases.Field]interface{} {
+	return map[usecases.Field]interface{}{
+		usecases.IdField:        j.ID,
+		usecases.AccountIdField: j.AccountID,
+		usecases.StatusField:    string(j.Status),
+	}
+}
+
+// ImportJobRepository keeps import jobs in memory.
+type ImportJobRepository struct {
+	Jobs   domain.ImportJobs
+	nextId uint64
+}
+
+func (r *ImportJobRepository) FindAll(filter *usecases.Filter) (*domain.ImportJobs, error) {
+	jobs := make(domain.ImportJobs, 0)
+	for _, v := range r.Jobs {
+		if Match(filter, importJobRow(v)) {
+			jobs = append(jobs, v)
+		}
+	}
+	return &jobs, nil
+}
+
+func (r *ImportJobRepository) Find(filter *usecases.Filter) (*domain.ImportJob, error) {
+	for _, v := range r.Jobs {
+		if Match(filter, importJobRow(v)) {
+			job := v
+			return &job, nil
+		}
+	}
+	return nil, usecases.ErrNotFound
+}
+
+func (r *ImportJobRepository) Create(job domain.ImportJob) (*domain.ImportJob, error) {
+	for _, v := range r.Jobs {
+		if v.ID > r.nextId {
+			r.nextId = v.ID
+		}
+	}
+	r.nextId++
+	job.ID = r.nextId
+	r.Jobs = append(r.Jobs, job)
+	return &job, nil
+}
+
+func (r *ImportJobRepository) Store(job domain.ImportJob) error {
+	for i, v := range r.Jobs {
+		if v.ID == job.ID {
+			r.Jobs[i] = job
+			return nil
+		}
+	}
+	return usecases.ErrNotFound
+}
+
+// FileStorage keeps files in memory by key.
+type FileStorage struct {
+	Files map[string][]byte
+}
+
+func (s *FileStorage) Put(key string, data []byte) error {
+	if s.Files == nil {
+		s.Files = map[string][]byte{}
+	}
+	s.Files[key] = data
+	return nil
+}
+
+func (s *FileStorage) Open(key string) (io.ReadSeekCloser, error) {
+	data, ok := s.Files[key]
+	if !ok {
+		return nil, usecases.ErrNotFound
+	}
+	return nopCloser{bytes.NewReader(data)}, nil
+}
+
+func (s *FileStorage) Delete(key string) error {
+	if _, ok := s.Files[key]; !ok {
+		return usecases.ErrNotFound
+	}
+	delete(s.Files, key)
+	return nil
+}
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// MetadataProvider answers from Books by their ISBN-10 or ISBN-13 as written, and never fails.
+type MetadataProvider struct {
+	Books []domain.BookMetadata
+}
+
+func (p *MetadataProvider) LookupISBN(isbn string) (*domain.BookMetadata, error) {
+	for _, v := range p.Books {
+		if isbn == v.ISBN || isbn == v.ISBN13 {
+			book := v
+			return &book, nil
+		}
+	}
+	return nil, usecases.ErrNotFound
+}